@@ -0,0 +1,162 @@
+package govue
+
+import (
+	"context"
+	"encoding/xml"
+)
+
+// Attendance holds a student's absences and tardies for the school year, along
+// with per-period totals of each kind of absence.
+type Attendance struct {
+	XMLName xml.Name `xml:"Attendance"`
+
+	// Type is the way in which the school takes attendance; e.g. `Period` or `Daily`.
+	Type string `xml:",attr"`
+
+	// StartPeriod is the first period of the school day.
+	StartPeriod int `xml:",attr"`
+
+	// EndPeriod is the last period of the school day.
+	EndPeriod int `xml:",attr"`
+
+	// PeriodCount is the number of periods in the school day.
+	PeriodCount int `xml:",attr"`
+
+	// SchoolName is the name of the school taking attendance.
+	SchoolName string `xml:",attr"`
+
+	// Absences holds every day on which the student was marked absent or tardy
+	// for at least one period.
+	Absences []*Absence `xml:"Absences>Absence"`
+
+	// TotalExcused holds the number of excused absences in each period.
+	TotalExcused PeriodTotals `xml:"TotalExcused>PeriodTotal"`
+
+	// TotalUnexcused holds the number of unexcused absences in each period.
+	TotalUnexcused PeriodTotals `xml:"TotalUnexcused>PeriodTotal"`
+
+	// TotalTardies holds the number of tardies in each period.
+	TotalTardies PeriodTotals `xml:"TotalTardies>PeriodTotal"`
+
+	// TotalUnexcusedTardies holds the number of unexcused tardies in each period.
+	TotalUnexcusedTardies PeriodTotals `xml:"TotalUnexcusedTardies>PeriodTotal"`
+
+	// TotalActivities holds the number of school activities (field trips, etc...)
+	// that the student attended during each period.
+	TotalActivities PeriodTotals `xml:"TotalActivities>PeriodTotal"`
+}
+
+// An Absence is a single day on which the student missed or was late to class.
+type Absence struct {
+	// Date is the day of the absence.
+	Date GradebookDate `xml:"AbsenceDate,attr"`
+
+	// Reason is the reason given for the absence; e.g. `Illness`.
+	Reason string `xml:",attr"`
+
+	// Note is any comment added by the school's staff on the absence.
+	Note string `xml:",attr"`
+
+	// Description describes the absence when the student missed the whole day.
+	Description string `xml:"CodeAllDayDescription,attr"`
+
+	// Periods holds an entry for each period the student missed or was late to.
+	Periods []*AbsencePeriod `xml:"Periods>Period"`
+}
+
+// An AbsencePeriod is a single period that the student missed or was late to.
+type AbsencePeriod struct {
+	// Number is the period of the day.
+	Number int `xml:",attr"`
+
+	// Name is the kind of absence; e.g. `Tardy` or `Excused`.
+	Name string `xml:",attr"`
+
+	// Reason is the reason given for the absence.
+	Reason string `xml:",attr"`
+
+	// Course is the name of the class held during the period.
+	Course string `xml:",attr"`
+
+	// Teacher is the name of the class's instructor.
+	Teacher string `xml:"Staff,attr"`
+
+	// TeacherEmail is the email of the class's instructor.
+	TeacherEmail string `xml:"StaffEMail,attr"`
+
+	// TeacherGU is StudentVUE's internal ID for the class's instructor.
+	TeacherGU string `xml:"StaffGU,attr"`
+
+	// SchoolName is the name of the school at which the class is held.
+	SchoolName string `xml:",attr"`
+}
+
+// A PeriodTotal is the number of absences of one kind in a single period.
+type PeriodTotal struct {
+	// Number is the period of the day.
+	Number int `xml:",attr"`
+
+	// Total is the number of absences.
+	Total int `xml:",attr"`
+}
+
+// PeriodTotals holds the number of absences of one kind for each period.
+type PeriodTotals []*PeriodTotal
+
+// Sum returns the number of absences across all periods.
+func (pts PeriodTotals) Sum() int {
+	sum := 0
+
+	for _, pt := range pts {
+		sum += pt.Total
+	}
+
+	return sum
+}
+
+// ForPeriod returns the number of absences in the given period.
+func (pts PeriodTotals) ForPeriod(period int) int {
+	for _, pt := range pts {
+		if pt.Number == period {
+			return pt.Total
+		}
+	}
+
+	return 0
+}
+
+// GetStudentAttendance returns a student's attendance record for the school year.
+// It is shorthand for creating a Client with NewClient and calling its Attendance
+// method.
+func GetStudentAttendance(username, password, endpoint string) (*Attendance, error) {
+	return GetStudentAttendanceContext(context.Background(), username, password, endpoint)
+}
+
+// GetStudentAttendanceContext is like GetStudentAttendance, but aborts the
+// request when ctx is cancelled or its deadline passes.
+func GetStudentAttendanceContext(ctx context.Context, username, password, endpoint string) (*Attendance, error) {
+	return NewClient(username, password, WithEndpoint(endpoint)).AttendanceContext(ctx)
+}
+
+// Attendance returns the student's attendance record for the school year.
+func (c *Client) Attendance() (*Attendance, error) {
+	return c.AttendanceContext(context.Background())
+}
+
+// AttendanceContext is like Attendance, but aborts the request when ctx is
+// cancelled or its deadline passes.
+func (c *Client) AttendanceContext(ctx context.Context) (*Attendance, error) {
+	a := new(Attendance)
+
+	err := c.invoke(ctx, svueRequest{
+		methodName:   "Attendance",
+		params:       c.childParams(),
+		skipLoginLog: true,
+	}, "Attendance", a)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
@@ -0,0 +1,123 @@
+package govue
+
+import (
+	"context"
+	"encoding/xml"
+	"time"
+)
+
+// A Calendar holds a school's calendar of events for a single month.
+type Calendar struct {
+	XMLName xml.Name `xml:"CalendarListing"`
+
+	// SchoolStartDate is the first day of the school year.
+	SchoolStartDate GradebookDate `xml:"SchoolBegDate,attr"`
+
+	// SchoolEndDate is the last day of the school year.
+	SchoolEndDate GradebookDate `xml:",attr"`
+
+	// MonthStartDate is the first day of the calendar's month.
+	MonthStartDate GradebookDate `xml:"MonthBegDate,attr"`
+
+	// MonthEndDate is the last day of the calendar's month.
+	MonthEndDate GradebookDate `xml:",attr"`
+
+	// Events holds all of the month's events, including holidays and the due
+	// dates of the student's assignments.
+	Events []*CalendarEvent `xml:"EventLists>EventList"`
+}
+
+// A CalendarEventType is the kind of a CalendarEvent.
+type CalendarEventType string
+
+const (
+	// HolidayEvent is a day on which school is not in session.
+	HolidayEvent CalendarEventType = "Holiday"
+
+	// AssignmentEvent is the due date of one of the student's assignments.
+	AssignmentEvent CalendarEventType = "Assignment"
+
+	// RegularEvent is any other school event.
+	RegularEvent CalendarEventType = "Regular"
+)
+
+// A CalendarEvent is a single entry in a school's calendar.
+type CalendarEvent struct {
+	// Date is the day of the event.
+	Date GradebookDate `xml:",attr"`
+
+	// StartTime is the time of day at which the event begins, if it has one.
+	StartTime ClassTime `xml:",attr"`
+
+	// Title describes the event. For assignments, this includes the course,
+	// the assignment's name and its score.
+	Title string `xml:",attr"`
+
+	// Type is the kind of the event.
+	Type CalendarEventType `xml:"DayType,attr"`
+
+	// AssignmentGU is StudentVUE's internal ID for the assignment, if the event
+	// is an assignment.
+	AssignmentGU string `xml:"AGU,attr"`
+}
+
+// Time returns the date and time at which the event begins. Events without a
+// start time begin at midnight.
+func (ce *CalendarEvent) Time() time.Time {
+	d := ce.Date.Time
+
+	if ce.StartTime.IsZero() {
+		return d
+	}
+
+	st := ce.StartTime.Time
+
+	return time.Date(d.Year(), d.Month(), d.Day(), st.Hour(), st.Minute(), 0, 0, d.Location())
+}
+
+// EventsOfType returns the calendar's events of the given type.
+func (cal *Calendar) EventsOfType(t CalendarEventType) []*CalendarEvent {
+	var events []*CalendarEvent
+
+	for _, e := range cal.Events {
+		if e.Type == t {
+			events = append(events, e)
+		}
+	}
+
+	return events
+}
+
+// Calendar returns the school's calendar for the month containing the given date.
+func (c *Client) Calendar(month time.Time) (*Calendar, error) {
+	return c.CalendarContext(context.Background(), month)
+}
+
+// CalendarContext is like Calendar, but aborts the request when ctx is cancelled
+// or its deadline passes.
+func (c *Client) CalendarContext(ctx context.Context, month time.Time) (*Calendar, error) {
+	const requestDateFormat = "2006-01-02T15:04:05"
+
+	firstDay := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	cal := new(Calendar)
+
+	err := c.invoke(ctx, svueRequest{
+		methodName: "StudentCalendar",
+		params: &calendarParams{
+			ChildIntID:  c.childIndex,
+			RequestDate: firstDay.Format(requestDateFormat),
+		},
+		skipLoginLog: true,
+	}, "CalendarListing", cal)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cal, nil
+}
+
+type calendarParams struct {
+	ChildIntID  int
+	RequestDate string
+}
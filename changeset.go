@@ -38,6 +38,7 @@ type CourseAssignmentChange struct {
 	Before, After                          *Assignment
 	NameChange                             bool
 	ScoreChange, PointsChange              bool
+	StatusChange, LetterChange             bool
 	ScoreIncrease, PossibleScoreChange     bool
 	PointsIncrease, PossiblePointsIncrease bool
 	PreviousScore, NewScore                *AssignmentScore
@@ -160,8 +161,8 @@ func (cs *Changeset) diffCourseAssignments() {
 	for p, ac := range aMap {
 		bc := bMap[p]
 
-		am := ac.CurrentMark
-		bm := bc.CurrentMark
+		am := markOrEmpty(ac.CurrentMark)
+		bm := markOrEmpty(bc.CurrentMark)
 		cc := &CourseChange{Course: ac}
 
 		bAssignments := make([]*Assignment, len(bm.Assignments))
@@ -257,10 +258,13 @@ func (cc *CourseChange) diffAssignments(a, b *Assignment) {
 	scoreChange := (b.Score.Score - a.Score.Score) != 0
 	possibleScoreChange := (b.Score.PossibleScore - a.Score.PossibleScore) != 0
 
+	statusChange := a.Score.Status != b.Score.Status
+	letterChange := a.Score.Letter != b.Score.Letter
+
 	pointsChange := (b.Points.Points - a.Points.Points) != 0
 	possiblePointsChange := (b.Points.PossiblePoints - a.Points.PossiblePoints) != 0
 
-	if !nameChange && !scoreChange && !possibleScoreChange && !pointsChange && !possiblePointsChange {
+	if !nameChange && !scoreChange && !possibleScoreChange && !statusChange && !letterChange && !pointsChange && !possiblePointsChange {
 		return
 	}
 
@@ -273,6 +277,8 @@ func (cc *CourseChange) diffAssignments(a, b *Assignment) {
 		NameChange:             nameChange,
 		ScoreChange:            scoreChange,
 		PointsChange:           pointsChange,
+		StatusChange:           statusChange,
+		LetterChange:           letterChange,
 		ScoreIncrease:          scoreIncrease,
 		PossibleScoreChange:    possibleScoreChange,
 		PointsIncrease:         pointsIncrease,
@@ -286,6 +292,16 @@ func (cc *CourseChange) diffAssignments(a, b *Assignment) {
 	cc.AssignmentChanges = append(cc.AssignmentChanges, ca)
 }
 
+// markOrEmpty returns m, or an empty CourseMark if the course has no mark, so
+// that a course without one is compared as having no assignments.
+func markOrEmpty(m *CourseMark) *CourseMark {
+	if m == nil {
+		return &CourseMark{}
+	}
+
+	return m
+}
+
 func findCourse(courses map[int]*Course, id string) (*Course, int, bool) {
 	for k, c := range courses {
 		if c.ID.ID == id {
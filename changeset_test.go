@@ -0,0 +1,59 @@
+package govue
+
+import "testing"
+
+func TestDiffAssignmentsStatusAndLetter(t *testing.T) {
+	tests := []struct {
+		name             string
+		before, after    string
+		wantStatusChange bool
+		wantLetterChange bool
+	}{
+		{"not graded to missing", "Not Graded", "Missing", true, false},
+		{"letter grade", "B", "A", false, true},
+		{"letter to graded", "B", "9 out of 10", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, err := parseAssignmentScore(tt.before)
+
+			if err != nil {
+				t.Fatalf("parseAssignmentScore(%q) error = %v", tt.before, err)
+			}
+
+			after, err := parseAssignmentScore(tt.after)
+
+			if err != nil {
+				t.Fatalf("parseAssignmentScore(%q) error = %v", tt.after, err)
+			}
+
+			cc := new(CourseChange)
+			cc.diffAssignments(&Assignment{GradebookID: "1", Score: before}, &Assignment{GradebookID: "1", Score: after})
+
+			if len(cc.AssignmentChanges) != 1 {
+				t.Fatalf("diffAssignments() found %d changes, want 1", len(cc.AssignmentChanges))
+			}
+
+			ca := cc.AssignmentChanges[0]
+
+			if ca.StatusChange != tt.wantStatusChange {
+				t.Errorf("StatusChange = %t, want %t", ca.StatusChange, tt.wantStatusChange)
+			}
+
+			if ca.LetterChange != tt.wantLetterChange {
+				t.Errorf("LetterChange = %t, want %t", ca.LetterChange, tt.wantLetterChange)
+			}
+		})
+	}
+}
+
+func TestDiffAssignmentsUnchanged(t *testing.T) {
+	score, _ := parseAssignmentScore("Exempt")
+	cc := new(CourseChange)
+	cc.diffAssignments(&Assignment{GradebookID: "1", Score: score}, &Assignment{GradebookID: "1", Score: score})
+
+	if len(cc.AssignmentChanges) != 0 {
+		t.Errorf("diffAssignments() found %d changes, want 0", len(cc.AssignmentChanges))
+	}
+}
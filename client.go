@@ -0,0 +1,253 @@
+package govue
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// A Client holds a student's StudentVUE credentials and the district endpoint
+// to which requests are sent, so they only need to be supplied once. A Client
+// is safe for concurrent use by multiple goroutines.
+type Client struct {
+	username, password string
+
+	// parent indicates that the credentials belong to a parent account, and
+	// childIndex selects which of the parent's children requests are made for.
+	parent     bool
+	childIndex int
+
+	endpoint   string
+	userAgent  string
+	timeout    time.Duration
+	httpClient *http.Client
+
+	retryPolicy *RetryPolicy
+	rateLimiter *RateLimiter
+	location    *time.Location
+}
+
+// A ClientOption configures a Client created by NewClient.
+type ClientOption func(*Client)
+
+// WithHTTPClient makes the Client send its requests through hc instead of
+// http.DefaultClient. This allows connection pools and transports to be
+// shared or replaced.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithEndpoint sets the district's StudentVUE SOAP endpoint, which usually
+// looks like `https://<district host>/Service/PXPCommunication.asmx`.
+func WithEndpoint(endpoint string) ClientOption {
+	return func(c *Client) {
+		c.endpoint = endpoint
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithTimeout limits the time each request may take, including reading the
+// response body. The HTTP client given by WithHTTPClient is not modified.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithParentAccount makes the Client sign in with the credentials of a parent
+// account rather than a student account. Requests are made for the parent's
+// first child unless another is selected with Client.Child.
+func WithParentAccount() ClientOption {
+	return func(c *Client) {
+		c.parent = true
+	}
+}
+
+// NewClient returns a Client that signs in to StudentVUE as the given student.
+func NewClient(username, password string, opts ...ClientOption) *Client {
+	c := &Client{
+		username: username,
+		password: password,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.httpClient == nil {
+		c.httpClient = http.DefaultClient
+	}
+
+	if c.timeout > 0 {
+		hc := *c.httpClient
+		hc.Timeout = c.timeout
+		c.httpClient = &hc
+	}
+
+	return c
+}
+
+// Child returns a copy of the Client which makes its requests for the child at
+// the given index of the list returned by Children. The copy shares the
+// Client's credentials and HTTP client.
+func (c *Client) Child(index int) *Client {
+	cc := *c
+	cc.childIndex = index
+
+	return &cc
+}
+
+// Children returns the basic information of every student accessible with the
+// Client's credentials. A student account has only itself, while a parent
+// account has each of the parent's children, in the order used by Child.
+func (c *Client) Children() ([]*Student, error) {
+	return c.ChildrenContext(context.Background())
+}
+
+// ChildrenContext is like Children, but aborts the request when ctx is cancelled
+// or its deadline passes.
+func (c *Client) ChildrenContext(ctx context.Context) ([]*Student, error) {
+	resp := new(SVUESignInResponse)
+
+	if err := c.invoke(ctx, svueRequest{methodName: "ChildList"}, "ChildList", resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Students, nil
+}
+
+// SignIn validates the Client's credentials and returns the basic information of
+// the student for whom the Client makes its requests.
+func (c *Client) SignIn() (*Student, error) {
+	return c.SignInContext(context.Background())
+}
+
+// SignInContext is like SignIn, but aborts the request when ctx is cancelled
+// or its deadline passes.
+func (c *Client) SignInContext(ctx context.Context) (*Student, error) {
+	students, err := c.ChildrenContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if c.childIndex < 0 || c.childIndex >= len(students) {
+		return nil, SVUEError{
+			OrigError: fmt.Errorf("No child at index %d; the account has %d children", c.childIndex, len(students)),
+			Code:      UnexpectedError,
+		}
+	}
+
+	return students[c.childIndex], nil
+}
+
+// Gradebook returns the student's gradebook for the school's current grading period.
+func (c *Client) Gradebook() (*Gradebook, error) {
+	return c.GradebookForPeriodContext(context.Background(), -1)
+}
+
+// GradebookContext is like Gradebook, but aborts the request when ctx is
+// cancelled or its deadline passes.
+func (c *Client) GradebookContext(ctx context.Context) (*Gradebook, error) {
+	return c.GradebookForPeriodContext(ctx, -1)
+}
+
+// GradebookForPeriod returns the student's gradebook for the grading period
+// at the given index of Gradebook.GradingPeriods. A negative index selects
+// the current grading period.
+func (c *Client) GradebookForPeriod(gradingPeriodIndex int) (*Gradebook, error) {
+	return c.GradebookForPeriodContext(context.Background(), gradingPeriodIndex)
+}
+
+// GradebookForPeriodContext is like GradebookForPeriod, but aborts the request
+// when ctx is cancelled or its deadline passes.
+func (c *Client) GradebookForPeriodContext(ctx context.Context, gradingPeriodIndex int) (*Gradebook, error) {
+	gb := new(Gradebook)
+
+	err := c.invoke(ctx, c.gradebookRequest(gradingPeriodIndex), "Gradebook", gb)
+
+	if err != nil {
+		return nil, err
+	}
+
+	setCurrentMarks(gb)
+
+	return gb, nil
+}
+
+// Call invokes an arbitrary PXPWebServices method. params is encoded with
+// encoding/xml as the method's `<Parms>` document and may be nil for methods
+// which take no parameters. The method's result document is decoded into out
+// with encoding/xml, unless out is nil.
+//
+// Call allows methods without a dedicated wrapper to be reached; errors are
+// reported in the same way as for the Client's other methods.
+func (c *Client) Call(ctx context.Context, methodName string, params, out interface{}) error {
+	return c.invoke(ctx, svueRequest{
+		methodName:   methodName,
+		params:       params,
+		skipLoginLog: true,
+	}, "", out)
+}
+
+// invoke calls the method described by sr and decodes its result document,
+// which must be named expectedElement, into v. Dates in v are anchored to the
+// Client's location. The request is retried according to the Client's RetryPolicy.
+func (c *Client) invoke(ctx context.Context, sr svueRequest, expectedElement string, v interface{}) error {
+	body, err := c.encodeRequest(sr)
+
+	if err != nil {
+		return err
+	}
+
+	err = c.retry(ctx, sr.methodName, v, func() error {
+		return c.callApi(ctx, strings.NewReader(body), expectedElement, v)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	localize(v, c.location)
+
+	return nil
+}
+
+// gradebookRequest returns the request for the gradebook of the grading period
+// at the given index, or of the current grading period if the index is negative.
+func (c *Client) gradebookRequest(gradingPeriodIndex int) svueRequest {
+	params := &gradebookParams{ChildIntID: c.childIndex}
+
+	if gradingPeriodIndex >= 0 {
+		params.ReportPeriod = &gradingPeriodIndex
+	}
+
+	return svueRequest{
+		methodName:   "Gradebook",
+		params:       params,
+		skipLoginLog: true,
+	}
+}
+
+func (c *Client) childParams() *childParams {
+	return &childParams{ChildIntID: c.childIndex}
+}
+
+type childParams struct {
+	ChildIntID int
+}
+
+type gradebookParams struct {
+	ChildIntID   int
+	ReportPeriod *int `xml:",omitempty"`
+}
@@ -1,10 +1,16 @@
 package govue
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/xml"
+	"errors"
+	"fmt"
 	"io"
+	"net/http"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 type SVUERespError struct {
@@ -12,15 +18,28 @@ type SVUERespError struct {
 	Message string   `xml:"ERROR_MESSAGE,attr"`
 }
 
+// An SVUEError is returned for any error reported by or encountered while talking
+// to StudentVUE. Its Code classifies the error, and it matches the sentinel error
+// for that code with errors.Is; e.g.
+//
+//	if errors.Is(err, govue.ErrInvalidCredentials) { ... }
+//
 // Codes are:
-//   0: StudentVue Server Error
-//   1: Unexpected Error
-//   2: Invalid Credentials
-//   3: Response Decoding Error
+//
+//	0: StudentVue Server Error
+//	1: Unexpected Error
+//	2: Invalid Credentials
+//	3: Response Decoding Error
+//	4: Account Locked
+//	5: Session Expired
+//	6: Server Under Maintenance
 type SVUEError struct {
 	OrigError error
 
 	Code int
+
+	// Message is the error message returned by the StudentVUE server, if any.
+	Message string
 }
 
 const (
@@ -28,6 +47,20 @@ const (
 	UnexpectedError
 	InvalidCredentialsError
 	DecodingError
+	AccountLockedError
+	SessionExpiredError
+	MaintenanceError
+)
+
+// Sentinel errors matched by an SVUEError with the corresponding Code.
+var (
+	ErrServer             = errors.New("govue: StudentVUE server error")
+	ErrUnexpected         = errors.New("govue: unexpected error")
+	ErrInvalidCredentials = errors.New("govue: invalid credentials")
+	ErrDecode             = errors.New("govue: response decoding error")
+	ErrAccountLocked      = errors.New("govue: account locked")
+	ErrSessionExpired     = errors.New("govue: session expired")
+	ErrMaintenance        = errors.New("govue: server under maintenance")
 )
 
 const (
@@ -35,138 +68,470 @@ const (
 	unexpectedErrorMsg         = "An unexpected error has occurred."
 	invalidCredentialsErrorMsg = "The username and/or password is invalid."
 	decodingErrorMsg           = "An internal error has occurred."
+	accountLockedErrorMsg      = "The account has been locked."
+	sessionExpiredErrorMsg     = "The session has expired."
+	maintenanceErrorMsg        = "The StudentVue server is down for maintenance."
 )
 
 func (s SVUEError) Error() string {
+	var msg string
+
 	switch s.Code {
 	case SVueServerError:
-		return sVueServerErrorMsg
+		msg = sVueServerErrorMsg
 	case UnexpectedError:
-		return unexpectedErrorMsg
+		msg = unexpectedErrorMsg
 	case InvalidCredentialsError:
-		return invalidCredentialsErrorMsg
+		msg = invalidCredentialsErrorMsg
 	case DecodingError:
-		return decodingErrorMsg
+		msg = decodingErrorMsg
+	case AccountLockedError:
+		msg = accountLockedErrorMsg
+	case SessionExpiredError:
+		msg = sessionExpiredErrorMsg
+	case MaintenanceError:
+		msg = maintenanceErrorMsg
 	default:
-		return unexpectedErrorMsg
+		msg = unexpectedErrorMsg
 	}
+
+	if s.Message != "" {
+		msg += " The server said: " + s.Message
+	}
+
+	if s.OrigError != nil {
+		msg += " (" + s.OrigError.Error() + ")"
+	}
+
+	return msg
 }
 
-func decodeSVUEResponse(body *bytes.Buffer) (*SVUEResponse, error) {
-	sVueResp := new(SVUEResponse)
+// Unwrap returns the underlying error, if any.
+func (s SVUEError) Unwrap() error {
+	return s.OrigError
+}
 
-	if err := xml.Unmarshal(body.Bytes(), sVueResp); err != nil {
-		return nil, SVUEError{
-			OrigError: err,
-			Code:      DecodingError,
-		}
+// Is reports whether target is the sentinel error for the SVUEError's Code.
+func (s SVUEError) Is(target error) bool {
+	switch s.Code {
+	case SVueServerError:
+		return target == ErrServer
+	case InvalidCredentialsError:
+		return target == ErrInvalidCredentials
+	case DecodingError:
+		return target == ErrDecode
+	case AccountLockedError:
+		return target == ErrAccountLocked
+	case SessionExpiredError:
+		return target == ErrSessionExpired
+	case MaintenanceError:
+		return target == ErrMaintenance
+	default:
+		return target == ErrUnexpected
 	}
+}
 
-	return sVueResp, nil
+const (
+	// maxErrorBodyLen is the number of bytes of a response body kept for
+	// diagnostics in SOAPFaultError and HTTPStatusError.
+	maxErrorBodyLen = 2048
+
+	// maxFaultBodyLen is the number of bytes of a non-2xx response body read in
+	// search of a SOAP fault, whose faultstring may hold a whole stack trace.
+	maxFaultBodyLen = 1 << 20
+)
+
+// A SOAPFaultError is returned when the StudentVUE server responds with a SOAP
+// fault rather than a result. It matches ErrServer with errors.Is.
+type SOAPFaultError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Fault is the decoded fault; its Code and String are the fault's faultcode
+	// and faultstring.
+	Fault SOAPFault
+
+	// Body holds the start of the response body.
+	Body string
 }
 
-func decodeStudentSignIn(sVueResp *SVUEResponse) (*Student, error) {
-	resp := new(SVUESignInResponse)
-	d, err := respIsOk(sVueResp, "ChildList")
+func (s SOAPFaultError) Error() string {
+	return fmt.Sprintf("The StudentVue server returned a SOAP fault (HTTP %d): %s: %s", s.StatusCode, s.Fault.Code, s.Fault.String)
+}
 
-	if err != nil {
-		return nil, err
+func (s SOAPFaultError) Is(target error) bool {
+	return target == ErrServer
+}
+
+// An HTTPStatusError is returned when the StudentVUE server responds with a
+// non-2xx HTTP status that does not carry a SOAP fault. It matches ErrServer
+// with errors.Is.
+type HTTPStatusError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Body holds the start of the response body.
+	Body string
+}
+
+func (h HTTPStatusError) Error() string {
+	return fmt.Sprintf("The StudentVue server responded with HTTP %d %s", h.StatusCode, http.StatusText(h.StatusCode))
+}
+
+func (h HTTPStatusError) Is(target error) bool {
+	return target == ErrServer
+}
+
+// decodeSVUEResponse decodes a successful response to a ProcessWebServiceRequest
+// while it is read from body. The result document is unescaped as it is read and
+// decoded in place into v; see decodeResult.
+func decodeSVUEResponse(statusCode int, body io.Reader, expectedElement string, v interface{}) error {
+	head := &headBuffer{max: maxErrorBodyLen}
+
+	// The decoder reads from br directly, without buffering of its own, so that
+	// br is positioned just after the result element's start tag once it is found.
+	br := bufio.NewReader(io.TeeReader(body, head))
+	d := xml.NewDecoder(br)
+
+	for {
+		t, err := d.Token()
+
+		if err != nil {
+			return SVUEError{
+				OrigError: err,
+				Code:      DecodingError,
+			}
+		}
+
+		start, ok := t.(xml.StartElement)
+
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "Fault":
+			fault := new(SOAPFault)
+
+			if err := d.DecodeElement(fault, &start); err != nil {
+				return SVUEError{
+					OrigError: err,
+					Code:      DecodingError,
+				}
+			}
+
+			return SOAPFaultError{
+				StatusCode: statusCode,
+				Fault:      *fault,
+				Body:       head.String(),
+			}
+		case "ProcessWebServiceRequestResult":
+			return decodeResult(&resultReader{r: br}, expectedElement, v)
+		}
 	}
+}
+
+// decodeErrorResponse returns the error for a response with a non-2xx status,
+// which is either a SOAPFaultError or an HTTPStatusError.
+func decodeErrorResponse(statusCode int, body []byte) error {
+	sVueResp := new(SVUEResponse)
 
-	if err = d.Decode(resp); err != nil {
-		return nil, SVUEError{
-			OrigError: err,
-			Code:      DecodingError,
+	if err := xml.Unmarshal(body, sVueResp); err == nil && sVueResp.Fault != nil {
+		return SOAPFaultError{
+			StatusCode: statusCode,
+			Fault:      *sVueResp.Fault,
+			Body:       truncateBody(body),
 		}
 	}
 
-	return resp.Students[0], nil
+	return HTTPStatusError{
+		StatusCode: statusCode,
+		Body:       truncateBody(body),
+	}
 }
 
-func decodeStudentGrades(sVueResp *SVUEResponse) (*Gradebook, error) {
-	gb := new(Gradebook)
-	d, err := respIsOk(sVueResp, "Gradebook")
+func truncateBody(body []byte) string {
+	if len(body) <= maxErrorBodyLen {
+		return string(body)
+	}
 
-	if err != nil {
-		return nil, err
+	return string(body[:maxErrorBodyLen]) + "..."
+}
+
+// setCurrentMarks points each course's CurrentMark at its mark for the
+// gradebook's current grading period. The mark is matched by the period's name
+// or, failing that, by the name of a grading period with the same dates or
+// spanning them; e.g. a semester mark for a school which grades by semester. A
+// course with no matching mark is left without a CurrentMark.
+func setCurrentMarks(gb *Gradebook) {
+	for _, c := range gb.Courses {
+		c.CurrentMark = currentMark(gb, c)
 	}
+}
 
-	if err = d.Decode(gb); err != nil {
-		return nil, SVUEError{
-			OrigError: err,
-			Code:      DecodingError,
-		}
+func currentMark(gb *Gradebook, c *Course) *CourseMark {
+	current := gb.CurrentGradingPeriod
+
+	if m := c.MarkFor(current); m != nil {
+		return m
 	}
 
-	for _, c := range gb.Courses {
-		if len(c.Marks) < 1 {
-			c.CurrentMark = &CourseMark{}
-		} else {
-			c.CurrentMark = c.Marks[0]
+	if current != nil {
+		for _, match := range []func(*GradingPeriod) bool{current.sameDates, current.within} {
+			for _, gp := range gb.GradingPeriods {
+				if !match(gp) {
+					continue
+				}
+
+				if m := c.MarkFor(gp); m != nil {
+					return m
+				}
+			}
 		}
 	}
 
-	return gb, nil
+	return nil
 }
 
-func respIsOk(sVueResp *SVUEResponse, expectedElement string) (*xml.Decoder, error) {
-	d := xml.NewDecoder(strings.NewReader(sVueResp.Result))
+// decodeResult decodes the result document read from r into v. If
+// expectedElement is empty, any result document other than an RT_ERROR is
+// accepted. A nil v only checks the result for an error.
+func decodeResult(r io.Reader, expectedElement string, v interface{}) error {
+	d := xml.NewDecoder(r)
 
-TokenLoop:
 	for {
 		t, err := d.Token()
 
-		if err == io.EOF {
-			return nil, SVUEError{
+		if err != nil {
+			return SVUEError{
 				OrigError: err,
 				Code:      DecodingError,
 			}
 		}
 
-		if _t, ok := t.(xml.StartElement); ok {
-			switch _t.Name.Local {
-			case expectedElement:
-				break TokenLoop
-			case "RT_ERROR":
-				return nil, decodeRespError(sVueResp)
-			default:
-				continue TokenLoop
-			}
-		} else {
+		start, ok := t.(xml.StartElement)
+
+		if !ok {
 			continue
 		}
+
+		switch {
+		case start.Name.Local == "RT_ERROR":
+			sErr := new(SVUERespError)
+
+			if err := d.DecodeElement(sErr, &start); err != nil {
+				return SVUEError{
+					OrigError: err,
+					Code:      DecodingError,
+				}
+			}
+
+			return decodeRespError(sErr)
+		case expectedElement == "" || start.Name.Local == expectedElement:
+			if v == nil {
+				return nil
+			}
+
+			if err := d.DecodeElement(v, &start); err != nil {
+				return SVUEError{
+					OrigError: err,
+					Code:      DecodingError,
+				}
+			}
+
+			return nil
+		}
+	}
+}
+
+// decodeRespError classifies an RT_ERROR result by its message. An error which
+// matches no known message is an UnexpectedError, since it is usually reported
+// by the application for the request itself, e.g. for an unsupported method.
+func decodeRespError(sErr *SVUERespError) error {
+	code := UnexpectedError
+	msg := strings.ToLower(sErr.Message)
+
+	switch {
+	case strings.Contains(msg, "the user name or password is incorrect"),
+		strings.Contains(msg, "invalid user id or password"):
+		code = InvalidCredentialsError
+	case strings.Contains(msg, "locked"),
+		strings.Contains(msg, "account is disabled"),
+		strings.Contains(msg, "account has been disabled"):
+		code = AccountLockedError
+	case strings.Contains(msg, "session") && (strings.Contains(msg, "expired") || strings.Contains(msg, "timed out")):
+		code = SessionExpiredError
+	case strings.Contains(msg, "maintenance"),
+		strings.Contains(msg, "temporarily unavailable"),
+		strings.Contains(msg, "currently unavailable"):
+		code = MaintenanceError
+	}
+
+	return SVUEError{
+		OrigError: nil,
+		Code:      code,
+		Message:   sErr.Message,
 	}
+}
 
-	return xml.NewDecoder(strings.NewReader(sVueResp.Result)), nil
+const (
+	cdataStart = "<![CDATA["
+	cdataEnd   = "]]>"
+)
+
+// A resultReader reads the text of the ProcessWebServiceRequestResult element,
+// which holds the escaped result document, from r and unescapes it. The text may
+// also be wrapped in CDATA sections, whose content is read as is. It stops at
+// the element's end tag.
+type resultReader struct {
+	r       *bufio.Reader
+	buf     [utf8.UTFMax]byte
+	pending []byte
+	cdata   bool
+	done    bool
 }
 
-func decodeRespError(sVueResp *SVUEResponse) error {
-	sErr := new(SVUERespError)
+func (rr *resultReader) ReadByte() (byte, error) {
+	if len(rr.pending) > 0 {
+		b := rr.pending[0]
+		rr.pending = rr.pending[1:]
 
-	err := xml.Unmarshal([]byte(sVueResp.Result), sErr)
+		return b, nil
+	}
+
+	if rr.done {
+		return 0, io.EOF
+	}
+
+	b, err := rr.r.ReadByte()
 
 	if err != nil {
-		return SVUEError{
-			OrigError: err,
-			Code:      DecodingError,
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
 		}
+
+		return 0, err
 	}
 
-	switch {
-	case strings.Contains(sErr.Message, "The user name or password is incorrect."):
-		return SVUEError{
-			OrigError: nil,
-			Code:      InvalidCredentialsError,
+	if rr.cdata {
+		if b == cdataEnd[0] {
+			if end, _ := rr.r.Peek(len(cdataEnd) - 1); string(end) == cdataEnd[1:] {
+				rr.r.Discard(len(end))
+				rr.cdata = false
+
+				return rr.ReadByte()
+			}
 		}
-	case strings.Contains(sErr.Message, "Invalid user id or password"):
-		return SVUEError{
-			OrigError: nil,
-			Code:      InvalidCredentialsError,
+
+		return b, nil
+	}
+
+	switch b {
+	case '<':
+		if start, _ := rr.r.Peek(len(cdataStart) - 1); string(start) == cdataStart[1:] {
+			rr.r.Discard(len(start))
+			rr.cdata = true
+
+			return rr.ReadByte()
 		}
+
+		rr.done = true
+
+		return 0, io.EOF
+	case '&':
+		if err := rr.readEntity(); err != nil {
+			return 0, err
+		}
+
+		return rr.ReadByte()
+	}
+
+	return b, nil
+}
+
+func (rr *resultReader) Read(p []byte) (int, error) {
+	for i := range p {
+		b, err := rr.ReadByte()
+
+		if err != nil {
+			return i, err
+		}
+
+		p[i] = b
+	}
+
+	return len(p), nil
+}
+
+// readEntity reads the rest of an entity or character reference, whose `&` has
+// been read, and queues its replacement text.
+func (rr *resultReader) readEntity() error {
+	const maxEntityLen = 10
+
+	name, err := rr.r.ReadSlice(';')
+
+	if err != nil || len(name) > maxEntityLen+1 {
+		return fmt.Errorf("Invalid entity in result document: &%.10s", name)
+	}
+
+	name = name[:len(name)-1]
+
+	switch string(name) {
+	case "lt":
+		rr.buf[0] = '<'
+		rr.pending = rr.buf[:1]
+	case "gt":
+		rr.buf[0] = '>'
+		rr.pending = rr.buf[:1]
+	case "amp":
+		rr.buf[0] = '&'
+		rr.pending = rr.buf[:1]
+	case "quot":
+		rr.buf[0] = '"'
+		rr.pending = rr.buf[:1]
+	case "apos":
+		rr.buf[0] = '\''
+		rr.pending = rr.buf[:1]
 	default:
-		return SVUEError{
-			OrigError: nil,
-			Code:      UnexpectedError,
+		if len(name) < 2 || name[0] != '#' {
+			return fmt.Errorf("Unknown entity in result document: &%s;", name)
+		}
+
+		var (
+			n   uint64
+			err error
+		)
+
+		if name[1] == 'x' {
+			n, err = strconv.ParseUint(string(name[2:]), 16, 32)
+		} else {
+			n, err = strconv.ParseUint(string(name[1:]), 10, 32)
 		}
+
+		if err != nil || !utf8.ValidRune(rune(n)) {
+			return fmt.Errorf("Invalid character reference in result document: &%s;", name)
+		}
+
+		rr.pending = rr.buf[:utf8.EncodeRune(rr.buf[:], rune(n))]
 	}
+
+	return nil
+}
+
+// A headBuffer keeps the first max bytes written to it.
+type headBuffer struct {
+	bytes.Buffer
+	max int
+}
+
+func (hb *headBuffer) Write(p []byte) (int, error) {
+	if room := hb.max - hb.Len(); room > 0 {
+		if len(p) > room {
+			hb.Buffer.Write(p[:room])
+		} else {
+			hb.Buffer.Write(p)
+		}
+	}
+
+	return len(p), nil
 }
@@ -0,0 +1,248 @@
+package govue
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func envelope(escapedResult string) string {
+	return `<?xml version="1.0" encoding="utf-8"?>` +
+		`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">` +
+		`<soap:Body><ProcessWebServiceRequestResponse xmlns="http://edupoint.com/webservices/">` +
+		`<ProcessWebServiceRequestResult>` + escapedResult + `</ProcessWebServiceRequestResult>` +
+		`</ProcessWebServiceRequestResponse></soap:Body></soap:Envelope>`
+}
+
+func escapedEnvelope(result string) string {
+	buf := new(bytes.Buffer)
+	xml.EscapeText(buf, []byte(result))
+
+	return envelope(buf.String())
+}
+
+func TestDecodeSVUEResponseUnescapesResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantName string
+	}{
+		{
+			name:     "escaped ampersand entity",
+			body:     envelope(`&lt;Gradebook&gt;&lt;Courses&gt;&lt;Course Title=&quot;Art &amp;amp; Design (A1)&quot;/&gt;&lt;/Courses&gt;&lt;/Gradebook&gt;`),
+			wantName: "Art & Design",
+		},
+		{
+			name:     "character references",
+			body:     envelope(`&lt;Gradebook&gt;&lt;Courses&gt;&lt;Course Title=&quot;Fran&#231;ais &#x49;&#233; (F1)&quot;/&gt;&lt;/Courses&gt;&lt;/Gradebook&gt;`),
+			wantName: "Français Ié",
+		},
+		{
+			name:     "CDATA",
+			body:     envelope(`<![CDATA[<Gradebook><Courses><Course Title="Art &amp; Design ]] (A1)"/></Courses></Gradebook>]]>`),
+			wantName: "Art & Design ]]",
+		},
+		{
+			name:     "CDATA and escaped text",
+			body:     envelope(`&lt;Gradebook&gt;<![CDATA[<Courses><Course Title="Art (A1)"/></Courses>]]>&lt;/Gradebook&gt;`),
+			wantName: "Art",
+		},
+		{
+			name:     "apostrophe",
+			body:     escapedEnvelope(`<Gradebook><Courses><Course Title="O'Neil's &lt;Lab&gt; (L1)"/></Courses></Gradebook>`),
+			wantName: "O'Neil's <Lab>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gb := new(Gradebook)
+
+			if err := decodeSVUEResponse(200, strings.NewReader(tt.body), "Gradebook", gb); err != nil {
+				t.Fatalf("decodeSVUEResponse() error = %v", err)
+			}
+
+			if len(gb.Courses) != 1 {
+				t.Fatalf("decoded %d courses, want 1", len(gb.Courses))
+			}
+
+			if got := gb.Courses[0].ID.Name; got != tt.wantName {
+				t.Errorf("course name = %q, want %q", got, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestDecodeSVUEResponseEmptyResult(t *testing.T) {
+	tests := map[string]string{
+		"empty":       envelope(""),
+		"self-closed": strings.Replace(envelope(""), "<ProcessWebServiceRequestResult></ProcessWebServiceRequestResult>", "<ProcessWebServiceRequestResult/>", 1),
+		"missing":     strings.Replace(envelope(""), "<ProcessWebServiceRequestResult></ProcessWebServiceRequestResult>", "", 1),
+		"bad entity":  envelope("&lt;Gradebook&gt;&bogus;&lt;/Gradebook&gt;"),
+		"empty CDATA": envelope("<![CDATA[]]>"),
+		"open CDATA":  envelope("<![CDATA[<Gradebook>"),
+	}
+
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := decodeSVUEResponse(200, strings.NewReader(body), "Gradebook", new(Gradebook))
+
+			if !errors.Is(err, ErrDecode) {
+				t.Errorf("decodeSVUEResponse() error = %v, want a decoding error", err)
+			}
+		})
+	}
+}
+
+func TestDecodeSVUEResponseError(t *testing.T) {
+	body := escapedEnvelope(`<RT_ERROR ERROR_MESSAGE="Invalid user id or password"/>`)
+	err := decodeSVUEResponse(200, strings.NewReader(body), "Gradebook", new(Gradebook))
+
+	if !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("decodeSVUEResponse() error = %v, want invalid credentials", err)
+	}
+}
+
+// decodeBuffered is the decode path which decodeSVUEResponse replaced: the
+// response is buffered, the result is unmarshalled into a string, scanned for
+// the expected element, then decoded again from the start.
+func decodeBuffered(body *bytes.Buffer, expectedElement string, v interface{}) error {
+	sVueResp := new(SVUEResponse)
+
+	if err := xml.Unmarshal(body.Bytes(), sVueResp); err != nil {
+		return err
+	}
+
+	d := xml.NewDecoder(strings.NewReader(sVueResp.Result))
+
+	for {
+		t, err := d.Token()
+
+		if err != nil {
+			return err
+		}
+
+		if start, ok := t.(xml.StartElement); ok && start.Name.Local == expectedElement {
+			break
+		}
+	}
+
+	return xml.NewDecoder(strings.NewReader(sVueResp.Result)).Decode(v)
+}
+
+func benchmarkGradebookEnvelope() []byte {
+	doc := new(strings.Builder)
+	doc.WriteString(`<Gradebook><ReportingPeriods><ReportPeriod Index="0" GradePeriod="Q1" StartDate="8/20/2024" EndDate="10/25/2024"/></ReportingPeriods>`)
+	doc.WriteString(`<ReportingPeriod GradePeriod="Q1" StartDate="8/20/2024" EndDate="10/25/2024"/><Courses>`)
+
+	for c := 0; c < 8; c++ {
+		fmt.Fprintf(doc, `<Course Period="%d" Title="Course %d (C%d)" Room="1" Staff="Teacher" StaffEMail="t@example.com"><Marks>`, c, c, c)
+
+		for m := 0; m < 4; m++ {
+			fmt.Fprintf(doc, `<Mark MarkName="Q%d" CalculatedScoreString="A" CalculatedScoreRaw="93"><Assignments>`, m+1)
+
+			for a := 0; a < 90; a++ {
+				fmt.Fprintf(doc, `<Assignment GradebookID="%d" Measure="Assignment &amp; %d" Type="Homework" Date="9/1/2024" DueDate="9/2/2024" Score="9 out of 10" ScoreType="Raw Score" Points="9.00 / 10.00" Notes="Notes"/>`, a, a)
+			}
+
+			doc.WriteString(`</Assignments></Mark>`)
+		}
+
+		doc.WriteString(`</Marks></Course>`)
+	}
+
+	doc.WriteString(`</Courses></Gradebook>`)
+
+	return []byte(escapedEnvelope(doc.String()))
+}
+
+// BenchmarkDecodeGradebook compares decoding a gradebook of 2,880 assignments
+// from a response body with the buffered path and with decodeSVUEResponse.
+func BenchmarkDecodeGradebook(b *testing.B) {
+	body := benchmarkGradebookEnvelope()
+
+	b.Run("buffered", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(body)))
+
+		for i := 0; i < b.N; i++ {
+			buf := new(bytes.Buffer)
+			buf.ReadFrom(bytes.NewReader(body))
+
+			if err := decodeBuffered(buf, "Gradebook", new(Gradebook)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("streaming", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(body)))
+
+		for i := 0; i < b.N; i++ {
+			if err := decodeSVUEResponse(200, bytes.NewReader(body), "Gradebook", new(Gradebook)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestSetCurrentMarks(t *testing.T) {
+	date := func(s string) GradebookDate {
+		gd, err := parseGradebookDate(s)
+
+		if err != nil {
+			t.Fatalf("parseGradebookDate(%q) error = %v", s, err)
+		}
+
+		return gd
+	}
+
+	q1 := &GradingPeriod{Index: 0, Name: "Q1", StartDate: date("8/20/2024"), EndDate: date("10/25/2024")}
+	s1 := &GradingPeriod{Index: 1, Name: "S1", StartDate: date("8/20/2024"), EndDate: date("1/17/2025")}
+	q3 := &GradingPeriod{Index: 2, Name: "Q3", StartDate: date("1/21/2025"), EndDate: date("3/28/2025")}
+
+	tests := []struct {
+		name    string
+		current *GradingPeriod
+		marks   []string
+		want    string
+	}{
+		{"by name", q1, []string{"S1", "Q1"}, "Q1"},
+		{"spanning period", q1, []string{"S1"}, "S1"},
+		{"single unmatched mark", q3, []string{"S1"}, ""},
+		{"no marks", q1, nil, ""},
+		{"no current period", nil, []string{"Q1"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := new(Course)
+
+			for _, name := range tt.marks {
+				c.Marks = append(c.Marks, &CourseMark{Name: name})
+			}
+
+			gb := &Gradebook{
+				GradingPeriods:       []*GradingPeriod{q1, s1, q3},
+				CurrentGradingPeriod: tt.current,
+				Courses:              []*Course{c},
+			}
+
+			setCurrentMarks(gb)
+
+			var got string
+
+			if c.CurrentMark != nil {
+				got = c.CurrentMark.Name
+			}
+
+			if got != tt.want {
+				t.Errorf("CurrentMark = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
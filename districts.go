@@ -0,0 +1,109 @@
+package govue
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+const (
+	districtInfoEndpoint = "https://support.edupoint.com/Service/HDInfoCommunication.asmx"
+	districtInfoUsername = "EdupointDistrictInfo"
+	districtInfoPassword = "Edup01nt"
+	districtInfoHandle   = "HDInfoServices"
+	districtInfoKey      = "5E4B7859-B805-474B-A833-FDB15D205D40"
+
+	pxpServicePath = "/Service/PXPCommunication.asmx"
+)
+
+// A District is a school district which uses StudentVUE.
+type District struct {
+	// ID is Edupoint's internal ID for the district.
+	ID string `xml:"DistrictID,attr"`
+
+	// Name is the name of the district.
+	Name string `xml:",attr"`
+
+	// Address is the address of the district's offices.
+	Address string `xml:",attr"`
+
+	// URL is the base URL of the district's StudentVUE web portal.
+	URL string `xml:"PvueURL,attr"`
+}
+
+// Endpoint returns the district's StudentVUE SOAP endpoint, which is passed to
+// WithEndpoint.
+func (d *District) Endpoint() (string, error) {
+	return EndpointForDistrictURL(d.URL)
+}
+
+// EndpointForDistrictURL derives the StudentVUE SOAP endpoint of a district
+// from the base URL of its web portal; e.g.
+//
+//	https://district.edupoint.com/PXP2_Login.aspx
+//
+// becomes `https://district.edupoint.com/Service/PXPCommunication.asmx`.
+// A URL without a scheme is assumed to use HTTPS.
+func EndpointForDistrictURL(baseURL string) (string, error) {
+	baseURL = strings.TrimSpace(baseURL)
+
+	if !strings.Contains(baseURL, "://") {
+		baseURL = "https://" + baseURL
+	}
+
+	u, err := url.Parse(baseURL)
+
+	if err != nil {
+		return "", err
+	}
+
+	if u.Host == "" {
+		return "", fmt.Errorf("Expected district URL with a host, received %s", baseURL)
+	}
+
+	p := strings.TrimRight(u.Path, "/")
+
+	if ext := strings.ToLower(path.Ext(p)); ext == ".aspx" || ext == ".asmx" {
+		p = path.Dir(p)
+	}
+
+	u.Path = strings.TrimRight(p, "/") + pxpServicePath
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	return u.String(), nil
+}
+
+// FindDistricts returns the districts using StudentVUE near the given ZIP code.
+// The lookup is made against Edupoint's district directory; opts may be used
+// to configure the HTTP client and user agent with which it is made.
+func FindDistricts(ctx context.Context, zip string, opts ...ClientOption) ([]*District, error) {
+	opts = append([]ClientOption{WithEndpoint(districtInfoEndpoint)}, opts...)
+	c := NewClient(districtInfoUsername, districtInfoPassword, opts...)
+
+	resp := new(struct {
+		Districts []*District `xml:"DistrictInfos>DistrictInfo"`
+	})
+
+	err := c.invoke(ctx, svueRequest{
+		methodName: "GetMatchingDistrictList",
+		params: &districtParams{
+			Key: districtInfoKey,
+			Zip: zip,
+		},
+		handleName: districtInfoHandle,
+	}, "DistrictLists", resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Districts, nil
+}
+
+type districtParams struct {
+	Key string
+	Zip string `xml:"MatchToDistrictZipCode"`
+}
@@ -0,0 +1,200 @@
+package govue
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"strings"
+)
+
+// A ReportCard is a report card which the school has published for one of
+// its reporting periods.
+type ReportCard struct {
+	// PeriodGU is StudentVUE's internal ID for the reporting period.
+	PeriodGU string `xml:"ReportingPeriodGU,attr"`
+
+	// PeriodName is the name of the reporting period.
+	PeriodName string `xml:"ReportingPeriodName,attr"`
+
+	// EndDate is when the reporting period ends.
+	EndDate GradebookDate `xml:",attr"`
+
+	// Message is any message added by the school to the report card.
+	Message string `xml:",attr"`
+
+	// DocumentGU is StudentVUE's internal ID for the report card's document,
+	// which is passed to Client.ReportCard to download it.
+	DocumentGU string `xml:",attr"`
+}
+
+// A StudentDocument is a document which the school has attached to the
+// student's records; e.g. test score reports and transcripts.
+type StudentDocument struct {
+	// DocumentGU is StudentVUE's internal ID for the document, which is passed
+	// to Client.Document to download it.
+	DocumentGU string `xml:",attr"`
+
+	// FileName is the name of the document's file.
+	FileName string `xml:"DocumentFileName,attr"`
+
+	// Date is when the document was attached.
+	Date GradebookDate `xml:"DocumentDate,attr"`
+
+	// Type is the school's category for the document.
+	Type string `xml:"DocumentType,attr"`
+
+	// Comment is any comment added by the school to the document.
+	Comment string `xml:"DocumentComment,attr"`
+}
+
+// A DocumentContent is a downloaded document. Reading from it yields the
+// document's decoded content.
+type DocumentContent struct {
+	io.Reader
+
+	// FileName is the name of the document's file.
+	FileName string
+
+	// MIMEType is the media type of the document, which is usually `application/pdf`.
+	MIMEType string
+}
+
+// documentData is the document element shared by the GetReportCardDocumentData
+// and GetContentOfAttachedDoc methods.
+type documentData struct {
+	DocumentGU string `xml:",attr"`
+	FileName   string `xml:",attr"`
+	DocType    string `xml:",attr"`
+	Base64Code string
+}
+
+func (dd *documentData) content() *DocumentContent {
+	mimeType := mime.TypeByExtension(filepath.Ext(dd.FileName))
+
+	if mimeType == "" {
+		if strings.EqualFold(dd.DocType, "PDF") {
+			mimeType = "application/pdf"
+		} else {
+			mimeType = "application/octet-stream"
+		}
+	}
+
+	return &DocumentContent{
+		Reader:   base64.NewDecoder(base64.StdEncoding, strings.NewReader(strings.TrimSpace(dd.Base64Code))),
+		FileName: dd.FileName,
+		MIMEType: mimeType,
+	}
+}
+
+// ReportCards lists the report cards available for the student.
+func (c *Client) ReportCards() ([]*ReportCard, error) {
+	return c.ReportCardsContext(context.Background())
+}
+
+// ReportCardsContext is like ReportCards, but aborts the request when ctx is
+// cancelled or its deadline passes.
+func (c *Client) ReportCardsContext(ctx context.Context) ([]*ReportCard, error) {
+	resp := new(struct {
+		ReportCards []*ReportCard `xml:"RCReportingPeriods>RCReportingPeriod"`
+	})
+
+	err := c.invoke(ctx, svueRequest{
+		methodName:   "GetReportCardInitialData",
+		params:       c.childParams(),
+		skipLoginLog: true,
+	}, "RCReportingPeriodData", resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.ReportCards, nil
+}
+
+// ReportCard downloads the report card with the given ReportCard.DocumentGU.
+func (c *Client) ReportCard(documentGU string) (*DocumentContent, error) {
+	return c.ReportCardContext(context.Background(), documentGU)
+}
+
+// ReportCardContext is like ReportCard, but aborts the request when ctx is
+// cancelled or its deadline passes.
+func (c *Client) ReportCardContext(ctx context.Context, documentGU string) (*DocumentContent, error) {
+	dd := new(documentData)
+
+	err := c.invoke(ctx, svueRequest{
+		methodName:   "GetReportCardDocumentData",
+		params:       &documentParams{DocumentGU: documentGU},
+		skipLoginLog: true,
+	}, "DocumentData", dd)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dd.content(), nil
+}
+
+// Documents lists the documents which the school has attached to the student's
+// records.
+func (c *Client) Documents() ([]*StudentDocument, error) {
+	return c.DocumentsContext(context.Background())
+}
+
+// DocumentsContext is like Documents, but aborts the request when ctx is
+// cancelled or its deadline passes.
+func (c *Client) DocumentsContext(ctx context.Context) ([]*StudentDocument, error) {
+	resp := new(struct {
+		Documents []*StudentDocument `xml:"StudentDocumentDatas>StudentDocumentData"`
+	})
+
+	err := c.invoke(ctx, svueRequest{
+		methodName:   "GetStudentDocumentInitialData",
+		params:       c.childParams(),
+		skipLoginLog: true,
+	}, "StudentDocuments", resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Documents, nil
+}
+
+// Document downloads the document with the given StudentDocument.DocumentGU.
+func (c *Client) Document(documentGU string) (*DocumentContent, error) {
+	return c.DocumentContext(context.Background(), documentGU)
+}
+
+// DocumentContext is like Document, but aborts the request when ctx is
+// cancelled or its deadline passes.
+func (c *Client) DocumentContext(ctx context.Context, documentGU string) (*DocumentContent, error) {
+	resp := new(struct {
+		Documents []*documentData `xml:"DocumentDatas>DocumentData"`
+	})
+
+	err := c.invoke(ctx, svueRequest{
+		methodName:   "GetContentOfAttachedDoc",
+		params:       &documentParams{DocumentGU: documentGU},
+		skipLoginLog: true,
+	}, "StudentAttachedDocumentData", resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Documents) < 1 {
+		return nil, SVUEError{
+			OrigError: fmt.Errorf("No document with DocumentGU `%s` was returned", documentGU),
+			Code:      DecodingError,
+		}
+	}
+
+	return resp.Documents[0].content(), nil
+}
+
+type documentParams struct {
+	DocumentGU string
+}
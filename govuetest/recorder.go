@@ -0,0 +1,263 @@
+package govuetest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// A Mode selects whether a Recorder records or replays traffic.
+type Mode int
+
+const (
+	// Record sends requests to the real server and saves each request and
+	// response pair to disk.
+	Record Mode = iota
+
+	// Replay serves responses from pairs saved by Record, without sending any
+	// request over the network.
+	Replay
+)
+
+const scrubbedCredential = "REDACTED"
+
+var credentialRegex = regexp.MustCompile(`(?s)<(userID|password)>.*?</(userID|password)>`)
+
+// An Interaction is a single request and response pair saved by a Recorder.
+type Interaction struct {
+	// MethodName is the name of the StudentVUE method which was called.
+	MethodName string `json:"methodName"`
+
+	// Request is the SOAP envelope of the request, with the userID and password
+	// elements scrubbed.
+	Request string `json:"request"`
+
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int `json:"statusCode"`
+
+	// ContentType is the Content-Type header of the response.
+	ContentType string `json:"contentType"`
+
+	// Response is the body of the response.
+	Response string `json:"response"`
+}
+
+// LoadInteraction reads an Interaction saved by a Recorder.
+func LoadInteraction(path string) (*Interaction, error) {
+	b, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	in := new(Interaction)
+
+	if err := json.Unmarshal(b, in); err != nil {
+		return nil, err
+	}
+
+	return in, nil
+}
+
+// Result returns the result document of the Interaction's response, which is
+// the XML decoded by govue; e.g. the `<Gradebook>` document.
+func (in *Interaction) Result() ([]byte, error) {
+	resp := new(struct {
+		Result string `xml:"Body>ProcessWebServiceRequestResponse>ProcessWebServiceRequestResult"`
+	})
+
+	if err := xml.Unmarshal([]byte(in.Response), resp); err != nil {
+		return nil, err
+	}
+
+	return []byte(resp.Result), nil
+}
+
+// Recorded returns a Document holding the result document of the Interaction
+// saved at path, so that captured traffic can be served by a Server.
+func Recorded(path string) Document {
+	return func() ([]byte, error) {
+		in, err := LoadInteraction(path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return in.Result()
+	}
+}
+
+// A Recorder is an http.RoundTripper which records StudentVUE traffic to a
+// directory, or replays traffic recorded earlier. It is installed with
+// govue.WithHTTPClient(&http.Client{Transport: recorder}).
+//
+// Requests are matched by their method name and parameters; repeated identical
+// requests are saved and replayed in order. Credentials are never written to disk.
+type Recorder struct {
+	// Mode selects whether the Recorder records or replays.
+	Mode Mode
+
+	// Dir is the directory in which interactions are saved.
+	Dir string
+
+	// Transport sends requests while recording. It defaults to
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+
+	mu   sync.Mutex
+	seen map[string]int
+}
+
+// NewRecorder returns a Recorder which records to or replays from dir.
+func NewRecorder(dir string, mode Mode) *Recorder {
+	return &Recorder{
+		Mode: mode,
+		Dir:  dir,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		body = b
+	}
+
+	scrubbed := credentialRegex.ReplaceAllString(string(body), "<$1>"+scrubbedCredential+"</$2>")
+	methodName, params := describeRequest([]byte(scrubbed))
+	path := r.nextPath(methodName, params)
+
+	if r.Mode == Replay {
+		return r.replay(req, path)
+	}
+
+	transport := r.Transport
+
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+
+	resp, err := transport.RoundTrip(out)
+
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	in := &Interaction{
+		MethodName:  methodName,
+		Request:     scrubbed,
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Response:    string(respBody),
+	}
+
+	if err := saveInteraction(path, in); err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, path string) (*http.Response, error) {
+	in, err := LoadInteraction(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("govuetest: no recorded interaction for request: %w", err)
+	}
+
+	header := make(http.Header)
+
+	if in.ContentType != "" {
+		header.Set("Content-Type", in.ContentType)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.StatusCode, http.StatusText(in.StatusCode)),
+		StatusCode:    in.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(in.Response)),
+		ContentLength: int64(len(in.Response)),
+		Request:       req,
+	}, nil
+}
+
+// nextPath returns the file for the next occurrence of the request.
+func (r *Recorder) nextPath(methodName, params string) string {
+	sum := sha256.Sum256([]byte(methodName + "\n" + params))
+	key := methodName + "-" + hex.EncodeToString(sum[:])[:12]
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.seen == nil {
+		r.seen = make(map[string]int)
+	}
+
+	n := r.seen[key]
+	r.seen[key]++
+
+	return filepath.Join(r.Dir, fmt.Sprintf("%s-%d.json", key, n))
+}
+
+func saveInteraction(path string, in *Interaction) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	e := json.NewEncoder(buf)
+	e.SetEscapeHTML(false)
+	e.SetIndent("", "  ")
+
+	if err := e.Encode(in); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// describeRequest returns the method name and the parameters, including the
+// web service's handle name, of a SOAP request.
+func describeRequest(body []byte) (methodName, params string) {
+	env := new(requestEnvelope)
+
+	if err := xml.Unmarshal(body, env); err != nil || env.Request.MethodName == "" {
+		return "unknown", string(body)
+	}
+
+	return env.Request.MethodName, env.Request.HandleName + "\n" + env.Request.ParamStr
+}
@@ -0,0 +1,461 @@
+// Package govuetest provides a fake StudentVUE server for testing code built
+// on govue without a live district account.
+package govuetest
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/jcorme/govue"
+)
+
+const (
+	// ServicePath is the path of the SOAP endpoint served by a Server.
+	ServicePath = "/Service/PXPCommunication.asmx"
+
+	invalidCredentialsMessage = "Invalid user id or password"
+)
+
+// A Document produces the XML result document which the Server returns for a
+// method.
+type Document func() ([]byte, error)
+
+// Struct returns a Document which encodes v with encoding/xml. A decoded
+// govue.Gradebook is encoded in the same shape as StudentVUE's own document.
+func Struct(v interface{}) Document {
+	return func() ([]byte, error) {
+		return xml.Marshal(v)
+	}
+}
+
+// File returns a Document read from the file at path, such as a fixture saved
+// from a real district server.
+func File(path string) Document {
+	return func() ([]byte, error) {
+		return os.ReadFile(path)
+	}
+}
+
+// Raw returns a Document holding the XML in s.
+func Raw(s string) Document {
+	return func() ([]byte, error) {
+		return []byte(s), nil
+	}
+}
+
+// A User is an account which may sign in to a Server.
+type User struct {
+	s *Server
+
+	username, password string
+	children           []*govue.Student
+	gradebooks         map[int]Document
+	documents          map[string]Document
+}
+
+// SetGradebook sets the gradebook returned for the grading period with the
+// given index. An index of -1 sets the gradebook for the current grading period,
+// which is also returned for any grading period without a gradebook of its own.
+func (u *User) SetGradebook(gradingPeriodIndex int, doc Document) *User {
+	u.s.mu.Lock()
+	defer u.s.mu.Unlock()
+
+	u.gradebooks[gradingPeriodIndex] = doc
+
+	return u
+}
+
+// SetDocument sets the result document returned for any other PXPWebServices
+// method, such as `Attendance` or `StudentInfo`.
+func (u *User) SetDocument(methodName string, doc Document) *User {
+	u.s.mu.Lock()
+	defer u.s.mu.Unlock()
+
+	u.documents[methodName] = doc
+
+	return u
+}
+
+// An Injection makes a Server misbehave for some of its requests. Exactly one
+// of ErrorMessage, Fault or Malformed should be set; Latency may be combined
+// with any of them, or used alone.
+type Injection struct {
+	// Method is the name of the method whose requests are affected. An empty
+	// Method affects every request.
+	Method string
+
+	// Times is the number of requests affected, after which the Injection is
+	// removed. Zero affects every request until ClearInjections is called.
+	Times int
+
+	// ErrorMessage makes the Server return an RT_ERROR result with the message.
+	ErrorMessage string
+
+	// Fault makes the Server return a SOAP fault with the HTTP status StatusCode.
+	Fault *govue.SOAPFault
+
+	// StatusCode is the HTTP status returned with Fault, or alone when neither
+	// ErrorMessage, Fault nor Malformed is set and it is non-zero. It defaults
+	// to 500 for a Fault.
+	StatusCode int
+
+	// Malformed makes the Server return a result document which is not
+	// well-formed XML.
+	Malformed bool
+
+	// Latency delays the Server's response.
+	Latency time.Duration
+}
+
+// A Request is a request received by a Server.
+type Request struct {
+	Username     string
+	Parent       bool
+	HandleName   string
+	MethodName   string
+	SkipLoginLog bool
+
+	// Params holds the decoded `<Parms>` document of the request.
+	Params string
+}
+
+// A Server is a fake StudentVUE server which speaks the ProcessWebServiceRequest
+// SOAP envelope. It authenticates requests against its Users and returns their
+// documents.
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	users      map[string]*User
+	latency    time.Duration
+	injections []*Injection
+	requests   []*Request
+}
+
+// NewServer starts and returns a new Server. The caller should call Close when
+// finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{users: make(map[string]*User)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Endpoint returns the Server's SOAP endpoint, which is passed to govue.WithEndpoint.
+func (s *Server) Endpoint() string {
+	return s.URL + ServicePath
+}
+
+// AddUser adds an account to the Server. Signing in to it returns children; a
+// student account should have exactly one.
+func (s *Server) AddUser(username, password string, children ...*govue.Student) *User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := &User{
+		s:          s,
+		username:   username,
+		password:   password,
+		children:   children,
+		gradebooks: make(map[int]Document),
+		documents:  make(map[string]Document),
+	}
+	s.users[username] = u
+
+	return u
+}
+
+// SetLatency delays every response of the Server by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency = d
+}
+
+// Inject adds an Injection to the Server. Injections are applied in the order
+// they were added; the first matching a request is used.
+func (s *Server) Inject(inj Injection) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.injections = append(s.injections, &inj)
+}
+
+// ClearInjections removes every Injection from the Server.
+func (s *Server) ClearInjections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.injections = nil
+}
+
+// Requests returns every request the Server has received, in order.
+func (s *Server) Requests() []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*Request(nil), s.requests...)
+}
+
+type requestEnvelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Request struct {
+		UserID       string `xml:"userID"`
+		Password     string `xml:"password"`
+		SkipLoginLog int    `xml:"skipLoginLog"`
+		Parent       int    `xml:"parent"`
+		HandleName   string `xml:"webServiceHandleName"`
+		MethodName   string `xml:"methodName"`
+		ParamStr     string `xml:"paramStr"`
+	} `xml:"Body>ProcessWebServiceRequest"`
+}
+
+type requestParams struct {
+	ChildIntID   int
+	ReportPeriod *int
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != ServicePath {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	env := new(requestEnvelope)
+
+	if err := xml.NewDecoder(r.Body).Decode(env); err != nil {
+		writeFault(w, http.StatusBadRequest, &govue.SOAPFault{
+			Code:   "soap:Client",
+			String: fmt.Sprintf("Unable to read request: %s", err),
+		})
+
+		return
+	}
+
+	req := &Request{
+		Username:     env.Request.UserID,
+		Parent:       env.Request.Parent == 1,
+		HandleName:   env.Request.HandleName,
+		MethodName:   env.Request.MethodName,
+		SkipLoginLog: env.Request.SkipLoginLog == 1,
+		Params:       env.Request.ParamStr,
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	latency := s.latency
+	inj := s.takeInjection(req.MethodName)
+	u, ok := s.users[env.Request.UserID]
+	s.mu.Unlock()
+
+	if inj != nil && inj.Latency > 0 {
+		latency += inj.Latency
+	}
+
+	if latency > 0 {
+		t := time.NewTimer(latency)
+
+		select {
+		case <-t.C:
+		case <-r.Context().Done():
+			t.Stop()
+
+			return
+		}
+	}
+
+	if inj != nil {
+		switch {
+		case inj.ErrorMessage != "":
+			writeError(w, inj.ErrorMessage)
+
+			return
+		case inj.Fault != nil:
+			status := inj.StatusCode
+
+			if status == 0 {
+				status = http.StatusInternalServerError
+			}
+
+			writeFault(w, status, inj.Fault)
+
+			return
+		case inj.Malformed:
+			writeResult(w, []byte("<"+req.MethodName+"><Unclosed attr=\"1\">"))
+
+			return
+		case inj.StatusCode != 0:
+			w.WriteHeader(inj.StatusCode)
+
+			return
+		}
+	}
+
+	if !ok || u.password != env.Request.Password {
+		writeError(w, invalidCredentialsMessage)
+
+		return
+	}
+
+	params := new(requestParams)
+
+	if env.Request.ParamStr != "" {
+		if err := xml.Unmarshal([]byte(env.Request.ParamStr), params); err != nil {
+			writeError(w, fmt.Sprintf("Unable to read parameters: %s", err))
+
+			return
+		}
+	}
+
+	doc, err := s.document(u, req.MethodName, params)
+
+	if err != nil {
+		writeError(w, err.Error())
+
+		return
+	}
+
+	result, err := doc()
+
+	if err != nil {
+		writeFault(w, http.StatusInternalServerError, &govue.SOAPFault{
+			Code:   "soap:Server",
+			String: fmt.Sprintf("Unable to produce document: %s", err),
+		})
+
+		return
+	}
+
+	writeResult(w, result)
+}
+
+// takeInjection returns the first Injection matching the method, removing it
+// once it has been used up. s.mu must be held.
+func (s *Server) takeInjection(methodName string) *Injection {
+	for i, inj := range s.injections {
+		if inj.Method != "" && inj.Method != methodName {
+			continue
+		}
+
+		if inj.Times > 0 {
+			inj.Times--
+
+			if inj.Times == 0 {
+				s.injections = append(s.injections[:i], s.injections[i+1:]...)
+			}
+		}
+
+		return inj
+	}
+
+	return nil
+}
+
+func (s *Server) document(u *User, methodName string, params *requestParams) (Document, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch methodName {
+	case "ChildList":
+		return Struct(struct {
+			XMLName  xml.Name         `xml:"ChildList"`
+			Children []*govue.Student `xml:"Child"`
+		}{Children: u.children}), nil
+	case "Gradebook":
+		period := -1
+
+		if params.ReportPeriod != nil {
+			period = *params.ReportPeriod
+		}
+
+		if doc, ok := u.gradebooks[period]; ok {
+			return doc, nil
+		}
+
+		if doc, ok := u.gradebooks[-1]; ok {
+			return doc, nil
+		}
+
+		return nil, fmt.Errorf("No gradebook is available for reporting period %d", period)
+	}
+
+	if doc, ok := u.documents[methodName]; ok {
+		return doc, nil
+	}
+
+	return nil, fmt.Errorf("Method %s is not supported", methodName)
+}
+
+func writeResult(w http.ResponseWriter, result []byte) {
+	buf := new(bytes.Buffer)
+
+	if err := xml.EscapeText(buf, result); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?>`+
+		`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:xsd="http://www.w3.org/2001/XMLSchema">`+
+		`<soap:Body><ProcessWebServiceRequestResponse xmlns="http://edupoint.com/webservices/">`+
+		`<ProcessWebServiceRequestResult>%s</ProcessWebServiceRequestResult>`+
+		`</ProcessWebServiceRequestResponse></soap:Body></soap:Envelope>`, buf.String())
+}
+
+func writeError(w http.ResponseWriter, message string) {
+	result, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"RT_ERROR"`
+		Message string   `xml:"ERROR_MESSAGE,attr"`
+	}{Message: message})
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	writeResult(w, result)
+}
+
+func writeFault(w http.ResponseWriter, statusCode int, fault *govue.SOAPFault) {
+	buf := new(bytes.Buffer)
+	e := xml.NewEncoder(buf)
+
+	err := e.Encode(struct {
+		XMLName xml.Name `xml:"soap:Fault"`
+		Code    string   `xml:"faultcode"`
+		String  string   `xml:"faultstring"`
+		Actor   string   `xml:"faultactor,omitempty"`
+		Detail  *struct {
+			InnerXML string `xml:",innerxml"`
+		} `xml:"detail"`
+	}{
+		Code:   fault.Code,
+		String: fault.String,
+		Actor:  fault.Actor,
+		Detail: &fault.Detail,
+	})
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	w.WriteHeader(statusCode)
+
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?>`+
+		`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">`+
+		`<soap:Body>%s</soap:Body></soap:Envelope>`, buf.String())
+}
@@ -0,0 +1,194 @@
+package govuetest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jcorme/govue"
+	"github.com/jcorme/govue/govuetest"
+)
+
+const gradebookDoc = `<Gradebook>` +
+	`<ReportingPeriods><ReportPeriod Index="0" GradePeriod="Q1" StartDate="8/20/2024" EndDate="10/25/2024"/>` +
+	`<ReportPeriod Index="1" GradePeriod="Q2" StartDate="10/28/2024" EndDate="1/17/2025"/></ReportingPeriods>` +
+	`<ReportingPeriod GradePeriod="Q1" StartDate="8/20/2024" EndDate="10/25/2024"/>` +
+	`<Courses><Course Period="1" Title="Algebra II (M201)" Room="12" Staff="Smith" StaffEMail="smith@example.com">` +
+	`<Marks><Mark MarkName="Q1" CalculatedScoreString="A" CalculatedScoreRaw="93.5"><Assignments>` +
+	`<Assignment GradebookID="7" Measure="Quiz 1" Type="Quiz" Date="9/1/2024" DueDate="9/2/2024" Score="9 out of 10" ScoreType="Raw Score" Points="9.00 / 10.00" Notes=""/>` +
+	`</Assignments></Mark></Marks></Course></Courses>` +
+	`</Gradebook>`
+
+func newServer(t *testing.T) (*govuetest.Server, *govue.Client) {
+	t.Helper()
+
+	s := govuetest.NewServer()
+	t.Cleanup(s.Close)
+
+	s.AddUser("student", "secret", &govue.Student{Name: "Jane Doe", School: "Central High"}).
+		SetGradebook(-1, govuetest.Raw(gradebookDoc)).
+		SetGradebook(1, govuetest.Raw(strings.Replace(gradebookDoc, `MarkName="Q1"`, `MarkName="Q2"`, 1)))
+
+	return s, govue.NewClient("student", "secret", govue.WithEndpoint(s.Endpoint()))
+}
+
+func TestServerSignIn(t *testing.T) {
+	s, c := newServer(t)
+
+	st, err := c.SignIn()
+
+	if err != nil {
+		t.Fatalf("SignIn() error = %v", err)
+	}
+
+	if st.Name != "Jane Doe" || st.School != "Central High" {
+		t.Errorf("SignIn() = %+v, want Jane Doe of Central High", st)
+	}
+
+	reqs := s.Requests()
+
+	if len(reqs) != 1 || reqs[0].MethodName != "ChildList" || reqs[0].Username != "student" {
+		t.Errorf("Requests() = %+v, want a single ChildList request by student", reqs)
+	}
+}
+
+func TestServerSignInInvalidCredentials(t *testing.T) {
+	s, _ := newServer(t)
+
+	_, err := govue.NewClient("student", "wrong", govue.WithEndpoint(s.Endpoint())).SignIn()
+
+	if !errors.Is(err, govue.ErrInvalidCredentials) {
+		t.Errorf("SignIn() error = %v, want invalid credentials", err)
+	}
+}
+
+func TestServerGradebook(t *testing.T) {
+	_, c := newServer(t)
+
+	gb, err := c.Gradebook()
+
+	if err != nil {
+		t.Fatalf("Gradebook() error = %v", err)
+	}
+
+	if len(gb.GradingPeriods) != 2 || len(gb.Courses) != 1 {
+		t.Fatalf("Gradebook() has %d grading periods and %d courses, want 2 and 1", len(gb.GradingPeriods), len(gb.Courses))
+	}
+
+	course := gb.Courses[0]
+
+	if course.ID.ID != "M201" || course.CurrentMark == nil || course.CurrentMark.Name != "Q1" {
+		t.Errorf("Gradebook() course = %+v, want M201 with a Q1 mark", course)
+	}
+
+	if a := course.CurrentMark.Assignments[0]; a.Score.Score != 9 || a.Points.PossiblePoints != 10 {
+		t.Errorf("Gradebook() assignment = %+v, want 9 out of 10", a)
+	}
+
+	gb, err = c.GradebookForPeriod(1)
+
+	if err != nil {
+		t.Fatalf("GradebookForPeriod(1) error = %v", err)
+	}
+
+	if name := gb.Courses[0].Marks[0].Name; name != "Q2" {
+		t.Errorf("GradebookForPeriod(1) mark = %q, want Q2", name)
+	}
+}
+
+func TestServerInjections(t *testing.T) {
+	tests := []struct {
+		name  string
+		inj   govuetest.Injection
+		check func(t *testing.T, err error)
+	}{
+		{
+			name: "error message",
+			inj:  govuetest.Injection{ErrorMessage: "The system is down for maintenance"},
+			check: func(t *testing.T, err error) {
+				if !errors.Is(err, govue.ErrMaintenance) {
+					t.Errorf("error = %v, want maintenance", err)
+				}
+			},
+		},
+		{
+			name: "fault",
+			inj:  govuetest.Injection{Fault: &govue.SOAPFault{Code: "soap:Server", String: "Boom"}},
+			check: func(t *testing.T, err error) {
+				var fe govue.SOAPFaultError
+
+				if !errors.As(err, &fe) || fe.StatusCode != http.StatusInternalServerError || fe.Fault.String != "Boom" {
+					t.Errorf("error = %v, want a SOAP fault `Boom` with HTTP 500", err)
+				}
+			},
+		},
+		{
+			name: "malformed",
+			inj:  govuetest.Injection{Malformed: true},
+			check: func(t *testing.T, err error) {
+				if !errors.Is(err, govue.ErrDecode) {
+					t.Errorf("error = %v, want a decoding error", err)
+				}
+			},
+		},
+		{
+			name: "status code",
+			inj:  govuetest.Injection{StatusCode: http.StatusBadGateway},
+			check: func(t *testing.T, err error) {
+				var he govue.HTTPStatusError
+
+				if !errors.As(err, &he) || he.StatusCode != http.StatusBadGateway {
+					t.Errorf("error = %v, want HTTP 502", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, c := newServer(t)
+
+			tt.inj.Method = "Gradebook"
+			tt.inj.Times = 1
+			s.Inject(tt.inj)
+
+			if _, err := c.SignIn(); err != nil {
+				t.Fatalf("SignIn() error = %v, want the injection to affect only Gradebook", err)
+			}
+
+			_, err := c.Gradebook()
+			tt.check(t, err)
+
+			if _, err := c.Gradebook(); err != nil {
+				t.Errorf("Gradebook() after the injection was used up error = %v", err)
+			}
+		})
+	}
+}
+
+func TestServerLatencyInjection(t *testing.T) {
+	s, c := newServer(t)
+
+	s.Inject(govuetest.Injection{Times: 1, Latency: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.GradebookContext(ctx)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GradebookContext() error = %v, want the deadline to pass", err)
+	}
+
+	if d := time.Since(start); d >= time.Second {
+		t.Errorf("GradebookContext() returned after %s, want it to abort at the deadline", d)
+	}
+
+	if _, err := c.Gradebook(); err != nil {
+		t.Errorf("Gradebook() after the injection was used up error = %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package govue
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"regexp"
@@ -12,19 +13,33 @@ import (
 // A Gradebook holds a student's courses, including their grades and assignments in
 // those courses, and their school's reporting periods ((mid-)terms, semesters, etc...).
 type Gradebook struct {
-	XMLName xml.Name `xml:"Gradebook"`
+	XMLName xml.Name `xml:"Gradebook" json:"-"`
 
 	// GradingPeriod holds all the grading periods of the student's school.
 	// If the school uses a semester schedule with (mid-)terms, there will be
 	// eight reporting periods.
-	GradingPeriods []*GradingPeriod `xml:"ReportingPeriods>ReportPeriod"`
+	GradingPeriods []*GradingPeriod `xml:"ReportingPeriods>ReportPeriod" json:"gradingPeriods"`
 
 	// CurrentGradingPeriod is the school's current grading period.
-	CurrentGradingPeriod *GradingPeriod `xml:"ReportingPeriod"`
+	CurrentGradingPeriod *GradingPeriod `xml:"ReportingPeriod" json:"currentGradingPeriod"`
 
 	// Courses holds all of the student's classes, which should be ordered by
 	// the class's period in the student's schedule.
-	Courses []*Course `xml:"Courses>Course"`
+	Courses []*Course `xml:"Courses>Course" json:"courses"`
+}
+
+// UnmarshalJSON decodes a Gradebook encoded with json.Marshal, pointing each
+// Course's CurrentMark at its mark for the current grading period.
+func (gb *Gradebook) UnmarshalJSON(b []byte) error {
+	type gradebook Gradebook
+
+	if err := json.Unmarshal(b, (*gradebook)(gb)); err != nil {
+		return err
+	}
+
+	setCurrentMarks(gb)
+
+	return nil
 }
 
 // A GradingPeriod represents one grading period for a school.
@@ -32,48 +47,85 @@ type Gradebook struct {
 type GradingPeriod struct {
 	// Index is a zero-based index representing the GradingPeriod 's place in
 	// the GradingPeriods set.
-	Index int `xml:",attr"`
+	Index int `xml:",attr" json:"index"`
 
 	// Name is the name of the grading period.
-	Name string `xml:"GradePeriod,attr"`
+	Name string `xml:"GradePeriod,attr" json:"name"`
 
 	// StartDate is when the grading period begins.
-	StartDate GradebookDate `xml:",attr"`
+	StartDate GradebookDate `xml:",attr" json:"startDate"`
 
 	// EndDate is when the grading period ends.
-	EndDate GradebookDate `xml:",attr"`
+	EndDate GradebookDate `xml:",attr" json:"endDate"`
+}
+
+// sameDates reports whether gp and other begin and end on the same days.
+func (gp *GradingPeriod) sameDates(other *GradingPeriod) bool {
+	if gp.StartDate.IsZero() || gp.EndDate.IsZero() {
+		return false
+	}
+
+	return gp.StartDate.Equal(other.StartDate.Time) && gp.EndDate.Equal(other.EndDate.Time)
+}
+
+// within reports whether gp lies within other; e.g. a quarter within a semester.
+func (gp *GradingPeriod) within(other *GradingPeriod) bool {
+	if gp.StartDate.IsZero() || gp.EndDate.IsZero() || other.StartDate.IsZero() || other.EndDate.IsZero() {
+		return false
+	}
+
+	return !gp.StartDate.Before(other.StartDate.Time) && !gp.EndDate.After(other.EndDate.Time)
 }
 
 // A Course represents one of a student's classes.
 type Course struct {
 	// Period is the period of the day in which the student has this class.
-	Period int `xml:",attr"`
+	Period int `xml:",attr" json:"period"`
 
 	// ID holds identification information for this class, which includes
 	// its Name and ID within the school's/StudentVUE's systems.
-	ID CourseID `xml:"Title,attr"`
+	ID CourseID `xml:"Title,attr" json:"id"`
 
 	// Room is the room number of this class inside the school.
-	Room string `xml:",attr"`
+	Room string `xml:",attr" json:"room"`
 
 	// Teacher is the name of the instructor of this class.
-	Teacher string `xml:"Staff,attr"`
+	Teacher string `xml:"Staff,attr" json:"teacher"`
 
 	// TeacherEmail is the email of this class's instructor.
-	TeacherEmail string `xml:"StaffEMail,attr"`
+	TeacherEmail string `xml:"StaffEMail,attr" json:"teacherEmail"`
 
 	// Marks holds the student's grading, including assignments, information
 	//for each grading period.
-	Marks []*CourseMark `xml:"Marks>Mark"`
+	Marks []*CourseMark `xml:"Marks>Mark" json:"marks"`
 
-	// CurrentMark points to the mark for the current grading period.
-	CurrentMark *CourseMark `xml:"-"`
+	// CurrentMark points to the mark for the current grading period, or is nil
+	// if the course has no mark for it.
+	CurrentMark *CourseMark `xml:"-" json:"-"`
+}
+
+// MarkFor returns the course's mark for the given grading period, matched by
+// name, or nil if the course has no mark for it.
+func (c *Course) MarkFor(period *GradingPeriod) *CourseMark {
+	if period == nil {
+		return nil
+	}
+
+	name := strings.TrimSpace(period.Name)
+
+	for _, m := range c.Marks {
+		if strings.EqualFold(strings.TrimSpace(m.Name), name) {
+			return m
+		}
+	}
+
+	return nil
 }
 
 // A CourseMark holds a student's grades and assignments for a single grading period.
 type CourseMark struct {
 	// Name is the name of the grading period.
-	Name string `xml:"MarkName,attr"`
+	Name string `xml:"MarkName,attr" json:"name"`
 
 	// LetterGrade is the student's raw (number) grade mapped to a letter.
 	// Usually mapped as such:
@@ -82,19 +134,19 @@ type CourseMark struct {
 	//		70+ -> C
 	//		60+ -> D
 	//		Else -> F
-	LetterGrade string `xml:"CalculatedScoreString,attr"`
+	LetterGrade string `xml:"CalculatedScoreString,attr" json:"letterGrade"`
 
 	// RawGradeScore is the student's raw percentage grade for the grading period.
-	RawGradeScore float64 `xml:"CalculatedScoreRaw,attr"`
+	RawGradeScore float64 `xml:"CalculatedScoreRaw,attr" json:"rawGradeScore"`
 
 	// GradeSummaries holds the grade summaries for each of the course's weighted categories.
 	// For example, if a course weighs Tests and Homework as separate categories, those will
 	// be contained here with information including the category's weighted percentage and
 	// letter grade.
-	GradeSummaries []*AssignmentGradeCalc `xml:"GradeCalculationSummary>AssignmentGradeCalc"`
+	GradeSummaries []*AssignmentGradeCalc `xml:"GradeCalculationSummary>AssignmentGradeCalc" json:"gradeSummaries"`
 
 	// Assignments holds all of the course's assignments for the grading period.
-	Assignments []*Assignment `xml:"Assignments>Assignment"`
+	Assignments []*Assignment `xml:"Assignments>Assignment" json:"assignments"`
 }
 
 // AssignmentGradeCalc represents one of a course's weighted categories.
@@ -102,81 +154,138 @@ type CourseMark struct {
 // by the course's instructor.
 type AssignmentGradeCalc struct {
 	// Type is the name of the weighted category.
-	Type string `xml:",attr"`
+	Type string `xml:",attr" json:"type"`
 
 	// Weight is the weight of the category of the student's grade in percent.
-	Weight Percentage `xml:",attr"`
+	Weight Percentage `xml:",attr" json:"weight"`
 
 	// Points is the number of points earned by the student in this category.
-	Points float64 `xml:",attr"`
+	Points float64 `xml:",attr" json:"points"`
 
 	// PointsPossible is the number of points that can be earned by the student in this category.
-	PointsPossible float64 `xml:",attr"`
+	PointsPossible float64 `xml:",attr" json:"pointsPossible"`
 
 	// WeightedPercentage is the impact of this category on the student's overall
 	// grade in percent.
-	WeightedPercentage Percentage `xml:"WeightedPct,attr"`
+	WeightedPercentage Percentage `xml:"WeightedPct,attr" json:"weightedPercentage"`
 
 	// LetterGrade is the student's raw (number) grade mapped to a letter for this category.
-	LetterGrade string `xml:"CalculatedMark,attr"`
+	LetterGrade string `xml:"CalculatedMark,attr" json:"letterGrade"`
 }
 
 // An Assignment is a single entry into a course's gradebook by an instructor.
 type Assignment struct {
 	// GradebookID is the internal ID given to the assignment by StudentVUE.
-	GradebookID string `xml:",attr"`
+	GradebookID string `xml:",attr" json:"gradebookId"`
 
 	// Name is the name of the assignment entry.
-	Name string `xml:"Measure,attr"`
+	Name string `xml:"Measure,attr" json:"name"`
 
 	// Type is the weighted category to which the assignment belongs.
-	Type string `xml:",attr"`
+	Type string `xml:",attr" json:"type"`
 
 	// Date is the date on which the assignment was entered into the gradebook
 	// by the instructor.
-	Date GradebookDate `xml:",attr"`
+	Date GradebookDate `xml:",attr" json:"date"`
 
 	// DueDate is the date on which the assignment was due for the student.
-	DueDate GradebookDate `xml:",attr"`
+	DueDate GradebookDate `xml:",attr" json:"dueDate"`
 
 	// Score holds the student's earned and possible raw score of the assignment.
-	Score AssignmentScore `xml:",attr"`
+	Score AssignmentScore `xml:",attr" json:"score"`
 
 	// ScoreType is the kind of score represented by the Score field; e.g. `Raw Score.`
-	ScoreType string `xml:",attr"`
+	ScoreType string `xml:",attr" json:"scoreType"`
 
 	// Points is the number of points for which the assignment actually counted.
 	// For example, an assignment score may be out of 20, but the instructor may
 	// choose to scale it down to only be worth 5 points (towards calculating the
 	// student's grade) or scale it up to be worth 80 points.
-	Points AssignmentPoints `xml:",attr"`
+	Points AssignmentPoints `xml:",attr" json:"points"`
 
 	// Notes is any comment added by the instructor on the assignment entry.
-	Notes string `xml:",attr"`
+	Notes string `xml:",attr" json:"notes"`
+}
+
+// IsOverdue reports whether the assignment's due date had passed at now without
+// it having been turned in; i.e. it is missing, or has not been graded. An
+// assignment due on a day without a time of day is due by the end of that day.
+func (a *Assignment) IsOverdue(now time.Time) bool {
+	if a.DueDate.IsZero() || !now.After(a.dueBy()) {
+		return false
+	}
+
+	switch a.Score.Status {
+	case ScoreMissing:
+		return true
+	case ScoreNotGraded, ScoreNotDue, ScoreIncomplete:
+		return !a.Score.Graded
+	}
+
+	return false
+}
+
+// DaysUntil returns the number of calendar days from now until the assignment's
+// due date, counted in the due date's location. It is zero on the day the
+// assignment is due, negative once that day has passed, and zero if the
+// assignment has no due date.
+func (a *Assignment) DaysUntil(now time.Time) int {
+	if a.DueDate.IsZero() {
+		return 0
+	}
+
+	now = now.In(a.DueDate.Location())
+
+	dy, dm, dd := a.DueDate.Date()
+	ny, nm, nd := now.Date()
+
+	due := time.Date(dy, dm, dd, 0, 0, 0, 0, time.UTC)
+	today := time.Date(ny, nm, nd, 0, 0, 0, 0, time.UTC)
+
+	return int(due.Sub(today).Hours() / 24)
+}
+
+// dueBy returns the time by which the assignment is due.
+func (a *Assignment) dueBy() time.Time {
+	due := a.DueDate.Time
+
+	if h, m, sec := due.Clock(); h != 0 || m != 0 || sec != 0 {
+		return due
+	}
+
+	y, m, d := due.Date()
+
+	return time.Date(y, m, d+1, 0, 0, 0, 0, due.Location())
 }
 
 // A CourseID holds the identification information for a class.
 type CourseID struct {
 	// ID is the school's/StudentVUE's internal ID for the class.
-	ID string
+	ID string `json:"id"`
 
 	// Name is the official name of the class.
-	Name string
+	Name string `json:"name"`
 }
 
 func (cid *CourseID) UnmarshalXMLAttr(attr xml.Attr) error {
-	const nameRegex = "(.+?)\\s*(\\(.+?\\))"
-
-	r, err := regexp.Compile(nameRegex)
+	id, err := parseCourseID(attr.Value)
 
 	if err != nil {
 		return err
 	}
 
-	name := r.FindStringSubmatch(attr.Value)
+	*cid = id
+
+	return nil
+}
+
+var courseIDRegex = regexp.MustCompile("(.+?)\\s*(\\(.+?\\))")
+
+func parseCourseID(s string) (CourseID, error) {
+	name := courseIDRegex.FindStringSubmatch(s)
 
 	if len(name) != 3 {
-		return fmt.Errorf("Expected course name attribute in format `Course (ID)`, received %s and found %d regex matches", attr.Value, len(name)-1)
+		return CourseID{}, fmt.Errorf("Expected course name attribute in format `Course (ID)`, received %s and found %d regex matches", s, len(name)-1)
 	}
 
 	var (
@@ -194,15 +303,18 @@ func (cid *CourseID) UnmarshalXMLAttr(attr xml.Attr) error {
 	}
 
 	if id == "" {
-		return fmt.Errorf("Unable to parse out course name and ID from `%s`, got `%v`", attr.Value, name)
+		return CourseID{}, fmt.Errorf("Unable to parse out course name and ID from `%s`, got `%v`", s, name)
 	}
 
-	*cid = CourseID{
+	return CourseID{
 		ID:   id,
 		Name: cname,
-	}
+	}, nil
+}
 
-	return nil
+// MarshalXMLAttr encodes the CourseID in the format `Name (ID)`.
+func (cid CourseID) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: fmt.Sprintf("%s (%s)", cid.Name, cid.ID)}, nil
 }
 
 // A Percentage is a floating-point number representing a percentage.
@@ -211,21 +323,42 @@ type Percentage struct {
 }
 
 func (p *Percentage) UnmarshalXMLAttr(attr xml.Attr) error {
-	pct := attr.Value
+	pct, err := parsePercentage(attr.Value)
+
+	if err != nil {
+		return err
+	}
 
-	if rune(pct[len(pct)-1]) != '%' {
-		return fmt.Errorf("Expected percentage attribute in format `x%`, received %s", pct)
+	*p = pct
+
+	return nil
+}
+
+func parsePercentage(s string) (Percentage, error) {
+	if len(s) == 0 || rune(s[len(s)-1]) != '%' {
+		return Percentage{}, fmt.Errorf("Expected percentage attribute in format `x%%`, received %s", s)
 	}
 
-	f, err := strconv.ParseFloat(pct[:len(pct)-1], 64)
+	f, err := strconv.ParseFloat(s[:len(s)-1], 64)
 
 	if err != nil {
-		return err
+		return Percentage{}, err
 	}
 
-	*p = Percentage{f}
+	return Percentage{f}, nil
+}
+
+func (p Percentage) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: formatFloat(p.float64) + "%"}, nil
+}
 
-	return nil
+// MarshalJSON encodes the percentage as a number; e.g. `92.5` for 92.5%.
+func (p Percentage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.float64)
+}
+
+func (p *Percentage) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &p.float64)
 }
 
 // A GradebookDate holds a timestamp parsed from the format of StudentVUE's systems.
@@ -234,132 +367,519 @@ type GradebookDate struct {
 }
 
 func (gd *GradebookDate) UnmarshalXMLAttr(attr xml.Attr) error {
-	const gradebookDateFormat = "1/2/2006"
+	dt, err := parseGradebookDate(attr.Value)
+
+	if err != nil {
+		return err
+	}
 
-	dt, err := time.Parse(gradebookDateFormat, attr.Value)
+	*gd = dt
+
+	return nil
+}
+
+// UnmarshalXML decodes a GradebookDate held in an element's text, as in the
+// StudentInfo document. An empty element leaves the zero GradebookDate.
+func (gd *GradebookDate) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		*gd = GradebookDate{}
+
+		return nil
+	}
+
+	dt, err := parseGradebookDate(s)
+
+	if err != nil {
+		return err
+	}
+
+	*gd = dt
+
+	return nil
+}
+
+// MarshalXMLAttr encodes the date in the format of StudentVUE's systems. A zero
+// GradebookDate is omitted.
+func (gd GradebookDate) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if gd.IsZero() {
+		return xml.Attr{}, nil
+	}
+
+	return xml.Attr{Name: name, Value: gd.format()}, nil
+}
+
+// MarshalXML encodes the date as an element's text, as in the StudentInfo document.
+func (gd GradebookDate) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if gd.IsZero() {
+		return e.EncodeElement("", start)
+	}
+
+	return e.EncodeElement(gd.format(), start)
+}
+
+// MarshalJSON encodes a wall-clock date from StudentVUE as `2006-01-02`, or
+// `2006-01-02T15:04:05` if it has a time of day, without a time zone. A date
+// with a time zone, such as one anchored by WithLocation, is encoded in RFC 3339
+// format with its offset, which UnmarshalJSON restores as a fixed zone. A zero
+// GradebookDate is encoded as null.
+func (gd GradebookDate) MarshalJSON() ([]byte, error) {
+	const (
+		jsonDateFormat     = "2006-01-02"
+		jsonDateTimeFormat = "2006-01-02T15:04:05.999999999"
+	)
+
+	if gd.IsZero() {
+		return []byte("null"), nil
+	}
+
+	if gd.Location() != time.UTC {
+		return json.Marshal(gd.Format(time.RFC3339Nano))
+	}
+
+	if h, m, sec := gd.Clock(); h == 0 && m == 0 && sec == 0 && gd.Nanosecond() == 0 {
+		return json.Marshal(gd.Format(jsonDateFormat))
+	}
+
+	return json.Marshal(gd.Format(jsonDateTimeFormat))
+}
+
+func (gd *GradebookDate) UnmarshalJSON(b []byte) error {
+	var s *string
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	if s == nil || *s == "" {
+		*gd = GradebookDate{}
+
+		return nil
+	}
+
+	dt, err := parseGradebookDate(*s)
 
 	if err != nil {
 		return err
 	}
 
-	*gd = GradebookDate{dt}
+	*gd = dt
 
 	return nil
 }
 
+func (gd GradebookDate) format() string {
+	const (
+		gradebookDateFormat     = "1/2/2006"
+		gradebookDateTimeFormat = "1/2/2006 3:04:05 PM"
+	)
+
+	if h, m, sec := gd.Clock(); h == 0 && m == 0 && sec == 0 {
+		return gd.Format(gradebookDateFormat)
+	}
+
+	return gd.Format(gradebookDateTimeFormat)
+}
+
+// gradebookDateLayouts holds the formats in which StudentVUE's systems send
+// dates, in the order they are tried.
+var gradebookDateLayouts = []string{
+	"1/2/2006",
+	"1/2/2006 3:04:05 PM",
+	"1/2/2006 3:04 PM",
+	"1/2/2006 15:04:05",
+	"1/2/2006 15:04",
+	"2006-01-02",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+}
+
+// parseGradebookDate parses a date in any of gradebookDateLayouts. Dates without
+// a time zone, which is most of them, are parsed as wall-clock times in UTC; see
+// WithLocation.
+func parseGradebookDate(s string) (GradebookDate, error) {
+	s = strings.TrimSpace(s)
+
+	var firstErr error
+
+	for _, layout := range gradebookDateLayouts {
+		dt, err := time.Parse(layout, s)
+
+		if err == nil {
+			// A zero offset from a date with a time zone would be taken for a
+			// wall-clock time by GradebookDate.in, so it is kept distinct.
+			if layout == time.RFC3339 && dt.Location() == time.UTC {
+				dt = dt.In(time.FixedZone("UTC", 0))
+			}
+
+			return GradebookDate{dt}, nil
+		}
+
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return GradebookDate{}, firstErr
+}
+
+// in returns the date in loc. A wall-clock time is kept as is, only anchored to
+// loc, while a date which had its own time zone is converted to loc.
+func (gd GradebookDate) in(loc *time.Location) GradebookDate {
+	if gd.IsZero() || gd.Location() == loc {
+		return gd
+	}
+
+	if gd.Location() != time.UTC {
+		return GradebookDate{gd.In(loc)}
+	}
+
+	y, m, d := gd.Date()
+	h, min, sec := gd.Clock()
+
+	return GradebookDate{time.Date(y, m, d, h, min, sec, gd.Nanosecond(), loc)}
+}
+
+// A ScoreStatus describes the state of an assignment's score.
+type ScoreStatus int
+
+const (
+	// ScoreUnknown is the status of a score which was not decoded from
+	// StudentVUE, or which could not be parsed by a lenient decode.
+	ScoreUnknown ScoreStatus = iota
+
+	// ScoreGraded is the status of a numeric score; e.g. `9 out of 10` or `90`.
+	ScoreGraded
+
+	// ScoreNotGraded is the status of an assignment which has not been graded yet.
+	ScoreNotGraded
+
+	// ScoreNotDue is the status of an assignment which is not due yet.
+	ScoreNotDue
+
+	// ScoreNotForGrading is the status of an assignment which is either not to
+	// be graded yet or is in the gradebook just for organizational purposes.
+	ScoreNotForGrading
+
+	// ScoreMissing is the status of an assignment which the student has not
+	// turned in. It may carry a numeric score; e.g. `Missing (0 out of 10)`.
+	ScoreMissing
+
+	// ScoreExempt is the status of an assignment from which the student is
+	// exempt or excused.
+	ScoreExempt
+
+	// ScoreIncomplete is the status of an assignment which the student has not
+	// completed. It may carry a numeric score.
+	ScoreIncomplete
+
+	// ScoreLate is the status of an assignment which was turned in late. It may
+	// carry a numeric score.
+	ScoreLate
+
+	// ScoreLetterOnly is the status of a score given only as a letter grade;
+	// e.g. `B+`.
+	ScoreLetterOnly
+
+	// ScoreRubric is the status of a score given as a rubric level; e.g.
+	// `3 - Proficient`.
+	ScoreRubric
+)
+
+var scoreStatusNames = [...]string{
+	ScoreUnknown:       "unknown",
+	ScoreGraded:        "graded",
+	ScoreNotGraded:     "notGraded",
+	ScoreNotDue:        "notDue",
+	ScoreNotForGrading: "notForGrading",
+	ScoreMissing:       "missing",
+	ScoreExempt:        "exempt",
+	ScoreIncomplete:    "incomplete",
+	ScoreLate:          "late",
+	ScoreLetterOnly:    "letterOnly",
+	ScoreRubric:        "rubric",
+}
+
+func (ss ScoreStatus) String() string {
+	if ss < 0 || int(ss) >= len(scoreStatusNames) {
+		return fmt.Sprintf("ScoreStatus(%d)", int(ss))
+	}
+
+	return scoreStatusNames[ss]
+}
+
+func (ss ScoreStatus) MarshalText() ([]byte, error) {
+	if ss < 0 || int(ss) >= len(scoreStatusNames) {
+		return nil, fmt.Errorf("Unknown score status %d", int(ss))
+	}
+
+	return []byte(scoreStatusNames[ss]), nil
+}
+
+func (ss *ScoreStatus) UnmarshalText(b []byte) error {
+	for i, n := range scoreStatusNames {
+		if n == string(b) {
+			*ss = ScoreStatus(i)
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Unknown score status %s", b)
+}
+
 // An AssignmentScore holds the score information for a single assignment for a student.
 type AssignmentScore struct {
-	// Graded denotes whether the assignment has been graded or not.
-	Graded bool
+	// Status is the state of the score.
+	Status ScoreStatus `json:"status"`
+
+	// Graded denotes whether the assignment has been given a numeric score,
+	// held in Score and PossibleScore.
+	Graded bool `json:"graded"`
 
 	// NotDue indicates if the assignment is not due yet.
-	NotDue bool
+	NotDue bool `json:"notDue"`
 
 	// NotForGrading indicates that an assignment is either not to be graded yet
 	// or is in the gradebook just for organizational purposes (?)
-	NotForGrading bool
+	NotForGrading bool `json:"notForGrading"`
 
 	// Percentage indicates whether the score is a percentage rather than a raw score
-	Percentage bool
+	Percentage bool `json:"percentage"`
 
 	// Score is the number of points earned on the assignment by the student.
-	Score float64
+	Score float64 `json:"score"`
 
 	// PossibleScore is the number of points that could be earned by the student.
-	PossibleScore float64
+	PossibleScore float64 `json:"possibleScore"`
+
+	// Letter is the letter grade or rubric level of a ScoreLetterOnly or
+	// ScoreRubric score; e.g. `B+` or `3 - Proficient`.
+	Letter string `json:"letter,omitempty"`
+
+	// Text holds the score as sent by StudentVUE; e.g. `Excused` for a score
+	// with status ScoreExempt. It is encoded by MarshalXMLAttr in place of the
+	// other fields unless they have been changed since the score was parsed.
+	Text string `json:"text,omitempty"`
+
+	// Raw holds the score as sent by StudentVUE if it could not be parsed by a
+	// lenient decode, in which case Status is ScoreUnknown and the other fields
+	// are zero.
+	Raw string `json:"raw,omitempty"`
 }
 
 func (as *AssignmentScore) UnmarshalXMLAttr(attr xml.Attr) error {
-	switch attr.Value {
-	case "Not Graded":
-		*as = AssignmentScore{
-			Graded:        false,
-			NotDue:        false,
-			NotForGrading: false,
-			Percentage:    false,
-			Score:         0,
-			PossibleScore: 0,
-		}
+	score, err := parseAssignmentScore(attr.Value)
 
-		return nil
-	case "Not Due":
-		*as = AssignmentScore{
-			Graded:        false,
-			NotDue:        true,
-			NotForGrading: false,
-			Percentage:    false,
-			Score:         0,
-			PossibleScore: 0,
-		}
+	if err != nil {
+		return err
+	}
 
-		return nil
-	case "":
-		*as = AssignmentScore{
-			Graded:        false,
-			NotDue:        false,
-			NotForGrading: true,
-			Percentage:    false,
-			Score:         0,
-			PossibleScore: 0,
-		}
+	*as = score
 
-		return nil
-	}
+	return nil
+}
 
-	const scoreRegex = "([\\d\\.]+)\\s*out\\s*of\\s*([\\d\\.]+)"
-	const pctScoreRegex = "([\\d\\.]+)"
+var (
+	numericScoreRegex = regexp.MustCompile(`^(\d+(?:\.\d+)?|\.\d+)\s*out\s*of\s*(\d+(?:\.\d+)?|\.\d+)$`)
+	pctScoreRegex     = regexp.MustCompile(`^(\d+(?:\.\d+)?|\.\d+)\s*%?$`)
+	statusScoreRegex  = regexp.MustCompile(`(?i)^(missing|exempt|excused|incomplete|late)\s*(?:\((.*)\))?$`)
+	rubricScoreRegex  = regexp.MustCompile(`^\d+(?:\.\d+)?\s*-\s*\S.*$`)
+	letterScoreRegex  = regexp.MustCompile(`^[A-Z]{1,2}[+-]?$`)
+)
 
-	r, err := regexp.Compile(scoreRegex)
+func parseAssignmentScore(s string) (AssignmentScore, error) {
+	as, err := parseScoreText(s)
 
 	if err != nil {
-		return err
+		return AssignmentScore{}, err
 	}
 
-	isPct := false
-	scores := r.FindStringSubmatch(attr.Value)
+	as.Text = s
+
+	return as, nil
+}
 
-	if len(scores) != 3 {
-		r, err = regexp.Compile(pctScoreRegex)
+// parseScoreText parses the fields of an AssignmentScore other than Text and Raw.
+func parseScoreText(s string) (AssignmentScore, error) {
+	text := strings.TrimSpace(s)
 
-		if err != nil {
-			return err
+	switch text {
+	case "Not Graded":
+		return AssignmentScore{
+			Status: ScoreNotGraded,
+		}, nil
+	case "Not Due":
+		return AssignmentScore{
+			Status: ScoreNotDue,
+			NotDue: true,
+		}, nil
+	case "":
+		return AssignmentScore{
+			Status:        ScoreNotForGrading,
+			NotForGrading: true,
+		}, nil
+	}
+
+	if m := statusScoreRegex.FindStringSubmatch(text); m != nil {
+		var status ScoreStatus
+
+		switch strings.ToLower(m[1]) {
+		case "missing":
+			status = ScoreMissing
+		case "exempt", "excused":
+			status = ScoreExempt
+		case "incomplete":
+			status = ScoreIncomplete
+		case "late":
+			status = ScoreLate
 		}
 
-		scores = r.FindStringSubmatch(attr.Value)
+		as := AssignmentScore{}
+
+		if inner := strings.TrimSpace(m[2]); inner != "" {
+			numeric, err := parseNumericScore(inner)
 
-		if len(scores) != 2 {
-			return fmt.Errorf("Expected assignment score in format `x out of y`, where x and y are real numbers, or `x`, where x is a percentage, received %s and parsed %d numbers", attr.Value, len(scores))
+			if err != nil {
+				return AssignmentScore{}, err
+			}
+
+			as = numeric
 		}
 
-		isPct = true
+		as.Status = status
+
+		return as, nil
 	}
 
-	fs, err := stringsToFloats(scores[1:])
+	if as, err := parseNumericScore(text); err == nil {
+		return as, nil
+	}
 
-	if err != nil {
-		return err
+	switch {
+	case rubricScoreRegex.MatchString(text):
+		return AssignmentScore{
+			Status: ScoreRubric,
+			Letter: text,
+		}, nil
+	case letterScoreRegex.MatchString(text):
+		return AssignmentScore{
+			Status: ScoreLetterOnly,
+			Letter: text,
+		}, nil
 	}
 
-	if isPct {
-		*as = AssignmentScore{
+	return AssignmentScore{}, fmt.Errorf("Expected assignment score in format `x out of y`, where x and y are real numbers, `x`, where x is a percentage, a status or a letter grade, received %s", s)
+}
+
+// parseNumericScore parses a score in the format `x out of y`, or `x` where x
+// is a percentage.
+func parseNumericScore(s string) (AssignmentScore, error) {
+	if scores := numericScoreRegex.FindStringSubmatch(s); scores != nil {
+		fs, err := stringsToFloats(scores[1:])
+
+		if err != nil {
+			return AssignmentScore{}, err
+		}
+
+		return AssignmentScore{
+			Status:        ScoreGraded,
 			Graded:        true,
-			NotDue:        false,
-			NotForGrading: false,
-			Percentage:    true,
 			Score:         fs[0],
-			PossibleScore: 100,
+			PossibleScore: fs[1],
+		}, nil
+	}
+
+	if scores := pctScoreRegex.FindStringSubmatch(s); scores != nil {
+		fs, err := stringsToFloats(scores[1:])
+
+		if err != nil {
+			return AssignmentScore{}, err
 		}
-	} else {
-		*as = AssignmentScore{
+
+		return AssignmentScore{
+			Status:        ScoreGraded,
 			Graded:        true,
-			NotDue:        false,
-			NotForGrading: false,
+			Percentage:    true,
 			Score:         fs[0],
-			PossibleScore: fs[1],
+			PossibleScore: 100,
+		}, nil
+	}
+
+	return AssignmentScore{}, fmt.Errorf("Expected numeric assignment score in format `x out of y` or `x`, received %s", s)
+}
+
+// MarshalXMLAttr encodes the score in the same format from which it was decoded;
+// e.g. `Not Graded` or `x out of y`. The Text of a score which has not been
+// changed since it was parsed, and the Raw value of a score with status
+// ScoreUnknown, are encoded as is.
+func (as AssignmentScore) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if as.Status == ScoreUnknown && as.Raw != "" {
+		return xml.Attr{Name: name, Value: as.Raw}, nil
+	}
+
+	if as.Text != "" && !as.edited() {
+		return xml.Attr{Name: name, Value: as.Text}, nil
+	}
+
+	var v string
+
+	switch as.Status {
+	case ScoreMissing, ScoreExempt, ScoreIncomplete, ScoreLate:
+		switch as.Status {
+		case ScoreMissing:
+			v = "Missing"
+		case ScoreExempt:
+			v = "Exempt"
+		case ScoreIncomplete:
+			v = "Incomplete"
+		case ScoreLate:
+			v = "Late"
+		}
+
+		if as.Graded {
+			v += " (" + as.numeric() + ")"
+		}
+	case ScoreLetterOnly, ScoreRubric:
+		v = as.Letter
+	default:
+		switch {
+		case as.NotForGrading:
+			v = ""
+		case as.NotDue:
+			v = "Not Due"
+		case !as.Graded:
+			v = "Not Graded"
+		default:
+			v = as.numeric()
 		}
 	}
 
-	return nil
+	return xml.Attr{Name: name, Value: v}, nil
+}
+
+// edited reports whether the score's fields differ from those parsed from its Text.
+func (as AssignmentScore) edited() bool {
+	parsed, err := parseScoreText(as.Text)
+	parsed.Text, parsed.Raw = as.Text, as.Raw
+
+	return err != nil || parsed != as
+}
+
+func (as AssignmentScore) numeric() string {
+	if as.Percentage {
+		return formatFloat(as.Score)
+	}
+
+	return fmt.Sprintf("%s out of %s", formatFloat(as.Score), formatFloat(as.PossibleScore))
 }
 
 // An AssignmentPoints holds an assignment's actual score for a student.
@@ -368,71 +888,120 @@ func (as *AssignmentScore) UnmarshalXMLAttr(attr xml.Attr) error {
 // to affect the student's actual grade differently.
 type AssignmentPoints struct {
 	// Graded denotes whether the assignment has been graded or not.
-	Graded bool
+	Graded bool `json:"graded"`
 
 	// Points is the number of points that the student received on the assignment.
-	Points float64
+	Points float64 `json:"points"`
 
 	// PossiblePoints is the number of points the student could receive on the assignment.
-	PossiblePoints float64
+	PossiblePoints float64 `json:"possiblePoints"`
+
+	// Text holds the points as sent by StudentVUE; e.g. `9.00 / 10.00`. It is
+	// encoded by MarshalXMLAttr in place of the other fields unless they have
+	// been changed since the points were parsed.
+	Text string `json:"text,omitempty"`
+
+	// Raw holds the points as sent by StudentVUE if they could not be parsed by
+	// a lenient decode, in which case the other fields are zero.
+	Raw string `json:"raw,omitempty"`
 }
 
 func (ap *AssignmentPoints) UnmarshalXMLAttr(attr xml.Attr) error {
-	if strings.Contains(attr.Value, "Points Possible") {
-		const pointsRegex = "([\\d\\.]+)\\s*Points\\s*Possible"
+	points, err := parseAssignmentPoints(attr.Value)
+
+	if err != nil {
+		return err
+	}
 
-		r, err := regexp.Compile(pointsRegex)
+	*ap = points
 
-		if err != nil {
-			return err
-		}
+	return nil
+}
+
+var (
+	possiblePointsRegex = regexp.MustCompile("([\\d\\.]+)\\s*Points\\s*Possible")
+	pointsRegex         = regexp.MustCompile("([\\d\\.]+)\\s*\\/\\s*([\\d\\.]+)")
+)
+
+func parseAssignmentPoints(s string) (AssignmentPoints, error) {
+	ap, err := parsePointsText(s)
 
-		possiblePoints := r.FindStringSubmatch(attr.Value)
+	if err != nil {
+		return AssignmentPoints{}, err
+	}
+
+	ap.Text = s
+
+	return ap, nil
+}
+
+// parsePointsText parses the fields of an AssignmentPoints other than Text and Raw.
+func parsePointsText(s string) (AssignmentPoints, error) {
+	if strings.Contains(s, "Points Possible") {
+		possiblePoints := possiblePointsRegex.FindStringSubmatch(s)
 
 		if len(possiblePoints) != 2 {
-			return fmt.Errorf("Expected points attribute in format `x Points Possible`, received %s and parsed %d values", attr.Value, len(possiblePoints))
+			return AssignmentPoints{}, fmt.Errorf("Expected points attribute in format `x Points Possible`, received %s and parsed %d values", s, len(possiblePoints))
 		}
 
 		val, err := stringsToFloats(possiblePoints[1:])
 
 		if err != nil {
-			return err
+			return AssignmentPoints{}, err
 		}
 
-		*ap = AssignmentPoints{
+		return AssignmentPoints{
 			Graded:         false,
 			Points:         0,
 			PossiblePoints: val[0],
-		}
-	} else {
-		const pointsRegex = "([\\d\\.]+)\\s*\\/\\s*([\\d\\.]+)"
+		}, nil
+	}
 
-		r, err := regexp.Compile(pointsRegex)
+	points := pointsRegex.FindStringSubmatch(s)
 
-		if err != nil {
-			return err
-		}
+	if len(points) != 3 {
+		return AssignmentPoints{}, fmt.Errorf("Expected points attribute in format `x/y`, received %s and parsed %d numbers", s, len(points))
+	}
 
-		points := r.FindStringSubmatch(attr.Value)
+	fs, err := stringsToFloats(points[1:])
 
-		if len(points) != 3 {
-			return fmt.Errorf("Expected points attribute in format `x/y`, received %s and parsed %d numbers", attr.Value, len(points))
-		}
+	if err != nil {
+		return AssignmentPoints{}, err
+	}
 
-		fs, err := stringsToFloats(points[1:])
+	return AssignmentPoints{
+		Graded:         true,
+		Points:         fs[0],
+		PossiblePoints: fs[1],
+	}, nil
+}
 
-		if err != nil {
-			return err
-		}
+// MarshalXMLAttr encodes the points in the format `x / y`, or `y Points Possible`
+// if the assignment has not been graded. The Text of points which have not been
+// changed since they were parsed, and the Raw value of points which are
+// otherwise zero, are encoded as is.
+func (ap AssignmentPoints) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if ap.Raw != "" && !ap.Graded && ap.Points == 0 && ap.PossiblePoints == 0 {
+		return xml.Attr{Name: name, Value: ap.Raw}, nil
+	}
 
-		*ap = AssignmentPoints{
-			Graded:         true,
-			Points:         fs[0],
-			PossiblePoints: fs[1],
-		}
+	if ap.Text != "" && !ap.edited() {
+		return xml.Attr{Name: name, Value: ap.Text}, nil
 	}
 
-	return nil
+	if !ap.Graded {
+		return xml.Attr{Name: name, Value: formatFloat(ap.PossiblePoints) + " Points Possible"}, nil
+	}
+
+	return xml.Attr{Name: name, Value: fmt.Sprintf("%s / %s", formatFloat(ap.Points), formatFloat(ap.PossiblePoints))}, nil
+}
+
+// edited reports whether the points' fields differ from those parsed from their Text.
+func (ap AssignmentPoints) edited() bool {
+	parsed, err := parsePointsText(ap.Text)
+	parsed.Text, parsed.Raw = ap.Text, ap.Raw
+
+	return err != nil || parsed != ap
 }
 
 func stringsToFloats(strs []string) ([]float64, error) {
@@ -450,3 +1019,7 @@ func stringsToFloats(strs []string) ([]float64, error) {
 
 	return fs, nil
 }
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
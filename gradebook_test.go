@@ -0,0 +1,265 @@
+package govue
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func TestGradebookDateJSONRoundTrip(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+
+	mustParse := func(s string) GradebookDate {
+		gd, err := parseGradebookDate(s)
+
+		if err != nil {
+			t.Fatalf("parseGradebookDate(%q) error = %v", s, err)
+		}
+
+		return gd
+	}
+
+	tests := []struct {
+		name     string
+		date     GradebookDate
+		wantJSON string
+	}{
+		{"wall-clock date", mustParse("9/2/2024"), `"2024-09-02"`},
+		{"wall-clock time", mustParse("9/2/2024 11:59:00 PM"), `"2024-09-02T23:59:00"`},
+		{"offset", mustParse("2024-09-02T10:00:00-07:00"), `"2024-09-02T10:00:00-07:00"`},
+		{"zoned UTC", mustParse("2024-09-02T10:00:00Z"), `"2024-09-02T10:00:00Z"`},
+		{"location", mustParse("9/2/2024").in(la), `"2024-09-02T00:00:00-07:00"`},
+		{"zero", GradebookDate{}, `null`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.date)
+
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+
+			if string(b) != tt.wantJSON {
+				t.Errorf("json.Marshal() = %s, want %s", b, tt.wantJSON)
+			}
+
+			var got GradebookDate
+
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+
+			if !got.Equal(tt.date.Time) {
+				t.Errorf("round trip = %v, want %v", got, tt.date)
+			}
+
+			if (got.Location() == time.UTC) != (tt.date.Location() == time.UTC) {
+				t.Errorf("round trip location = %v, want %v", got.Location(), tt.date.Location())
+			}
+		})
+	}
+}
+
+func TestAssignmentDaysUntilAfterJSONRoundTrip(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+
+	due, _ := parseGradebookDate("9/2/2024")
+	a := &Assignment{DueDate: due.in(la), Score: AssignmentScore{Status: ScoreNotGraded}}
+
+	b, err := json.Marshal(a)
+
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	cached := new(Assignment)
+
+	if err := json.Unmarshal(b, cached); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	// 10pm on September 2nd in Los Angeles.
+	now := time.Date(2024, 9, 3, 5, 0, 0, 0, time.UTC)
+
+	if got, want := cached.DaysUntil(now), a.DaysUntil(now); got != want {
+		t.Errorf("DaysUntil() after round trip = %d, want %d", got, want)
+	}
+
+	if cached.IsOverdue(now) {
+		t.Errorf("IsOverdue() after round trip = true, want false")
+	}
+}
+
+func TestAssignmentScoreMarshalXMLAttrAfterEdit(t *testing.T) {
+	parsed, err := parseAssignmentScore("Not Graded")
+
+	if err != nil {
+		t.Fatalf("parseAssignmentScore() error = %v", err)
+	}
+
+	graded := parsed
+	graded.Status, graded.Graded, graded.Score, graded.PossibleScore = ScoreGraded, true, 9, 10
+
+	edited := AssignmentScore{Raw: "??"}
+	edited.Status, edited.Graded, edited.Score, edited.PossibleScore = ScoreGraded, true, 9, 10
+
+	tests := []struct {
+		name  string
+		score AssignmentScore
+		want  string
+	}{
+		{"parsed", parsed, "Not Graded"},
+		{"graded after parse", graded, "9 out of 10"},
+		{"unparsed", AssignmentScore{Raw: "??"}, "??"},
+		{"graded after lenient decode", edited, "9 out of 10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attr, err := tt.score.MarshalXMLAttr(xml.Name{Local: "Score"})
+
+			if err != nil {
+				t.Fatalf("MarshalXMLAttr() error = %v", err)
+			}
+
+			if attr.Value != tt.want {
+				t.Errorf("MarshalXMLAttr() = %q, want %q", attr.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssignmentPointsMarshalXMLAttrAfterEdit(t *testing.T) {
+	edited := AssignmentPoints{Raw: "??"}
+	edited.Graded, edited.Points, edited.PossiblePoints = true, 9, 10
+
+	tests := []struct {
+		name   string
+		points AssignmentPoints
+		want   string
+	}{
+		{"unparsed", AssignmentPoints{Raw: "??"}, "??"},
+		{"graded after lenient decode", edited, "9 / 10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attr, err := tt.points.MarshalXMLAttr(xml.Name{Local: "Points"})
+
+			if err != nil {
+				t.Fatalf("MarshalXMLAttr() error = %v", err)
+			}
+
+			if attr.Value != tt.want {
+				t.Errorf("MarshalXMLAttr() = %q, want %q", attr.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAssignmentScore(t *testing.T) {
+	tests := []struct {
+		in   string
+		want AssignmentScore
+	}{
+		{"9 out of 10", AssignmentScore{Status: ScoreGraded, Graded: true, Score: 9, PossibleScore: 10}},
+		{".5 out of 1", AssignmentScore{Status: ScoreGraded, Graded: true, Score: 0.5, PossibleScore: 1}},
+		{"95%", AssignmentScore{Status: ScoreGraded, Graded: true, Percentage: true, Score: 95, PossibleScore: 100}},
+		{"95", AssignmentScore{Status: ScoreGraded, Graded: true, Percentage: true, Score: 95, PossibleScore: 100}},
+		{"Not Graded", AssignmentScore{Status: ScoreNotGraded}},
+		{"Not Due", AssignmentScore{Status: ScoreNotDue, NotDue: true}},
+		{"", AssignmentScore{Status: ScoreNotForGrading, NotForGrading: true}},
+		{"Missing", AssignmentScore{Status: ScoreMissing}},
+		{"Missing (0 out of 10)", AssignmentScore{Status: ScoreMissing, Graded: true, Score: 0, PossibleScore: 10}},
+		{"Exempt", AssignmentScore{Status: ScoreExempt}},
+		{"excused", AssignmentScore{Status: ScoreExempt}},
+		{"Late (8 out of 10)", AssignmentScore{Status: ScoreLate, Graded: true, Score: 8, PossibleScore: 10}},
+		{"Incomplete", AssignmentScore{Status: ScoreIncomplete}},
+		{"B+", AssignmentScore{Status: ScoreLetterOnly, Letter: "B+"}},
+		{" A ", AssignmentScore{Status: ScoreLetterOnly, Letter: "A"}},
+		{"3 - Proficient", AssignmentScore{Status: ScoreRubric, Letter: "3 - Proficient"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseAssignmentScore(tt.in)
+
+			if err != nil {
+				t.Fatalf("parseAssignmentScore(%q) error = %v", tt.in, err)
+			}
+
+			tt.want.Text = tt.in
+
+			if got != tt.want {
+				t.Errorf("parseAssignmentScore(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAssignmentScoreRejects(t *testing.T) {
+	for _, in := range []string{"abc 5", "5 out of", "out of 10", "Missing (x)", "Late (5 out of)", "b+", "95%%", "1.2.3"} {
+		t.Run(in, func(t *testing.T) {
+			if got, err := parseAssignmentScore(in); err == nil {
+				t.Errorf("parseAssignmentScore(%q) = %+v, want an error", in, got)
+			}
+		})
+	}
+}
+
+func TestAssignmentScoreMarshalXMLAttrKeepsText(t *testing.T) {
+	for _, in := range []string{"Excused", "missing", "9.00 out of 10.00", "95.0%", "Late (8.50 out of 10)", " B+ ", "Not Graded"} {
+		t.Run(in, func(t *testing.T) {
+			as, err := parseAssignmentScore(in)
+
+			if err != nil {
+				t.Fatalf("parseAssignmentScore(%q) error = %v", in, err)
+			}
+
+			attr, err := as.MarshalXMLAttr(xml.Name{Local: "Score"})
+
+			if err != nil {
+				t.Fatalf("MarshalXMLAttr() error = %v", err)
+			}
+
+			if attr.Value != in {
+				t.Errorf("MarshalXMLAttr() = %q, want %q", attr.Value, in)
+			}
+		})
+	}
+
+	as, _ := parseAssignmentScore("Excused")
+	as.Status = ScoreMissing
+
+	if attr, _ := as.MarshalXMLAttr(xml.Name{Local: "Score"}); attr.Value != "Missing" {
+		t.Errorf("MarshalXMLAttr() after changing the status = %q, want %q", attr.Value, "Missing")
+	}
+}
+
+func TestAssignmentPointsMarshalXMLAttrKeepsText(t *testing.T) {
+	ap, err := parseAssignmentPoints("9.00 / 10.00")
+
+	if err != nil {
+		t.Fatalf("parseAssignmentPoints() error = %v", err)
+	}
+
+	if attr, _ := ap.MarshalXMLAttr(xml.Name{Local: "Points"}); attr.Value != "9.00 / 10.00" {
+		t.Errorf("MarshalXMLAttr() = %q, want %q", attr.Value, "9.00 / 10.00")
+	}
+
+	ap.Points = 10
+
+	if attr, _ := ap.MarshalXMLAttr(xml.Name{Local: "Points"}); attr.Value != "10 / 10" {
+		t.Errorf("MarshalXMLAttr() after changing the points = %q, want %q", attr.Value, "10 / 10")
+	}
+}
@@ -0,0 +1,344 @@
+package govue
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A DecodeWarning describes an attribute of a gradebook which could not be
+// parsed by a lenient decode. The attribute is left at its zero value, except
+// for an assignment's Score and Points, which keep the value in their Raw field.
+type DecodeWarning struct {
+	// Course is the title of the course holding the attribute, if any.
+	Course string
+
+	// Assignment is the name of the assignment holding the attribute, if any.
+	Assignment string
+
+	// Attribute is the name of the XML attribute; e.g. `Score`.
+	Attribute string
+
+	// Value is the attribute's value as sent by StudentVUE.
+	Value string
+
+	// Err is the error with which parsing the value failed.
+	Err error
+}
+
+func (dw DecodeWarning) Error() string {
+	var where []string
+
+	if dw.Course != "" {
+		where = append(where, fmt.Sprintf("course %q", dw.Course))
+	}
+
+	if dw.Assignment != "" {
+		where = append(where, fmt.Sprintf("assignment %q", dw.Assignment))
+	}
+
+	where = append(where, fmt.Sprintf("attribute %s=%q", dw.Attribute, dw.Value))
+
+	return fmt.Sprintf("Unable to parse %s: %s", strings.Join(where, ", "), dw.Err)
+}
+
+func (dw DecodeWarning) Unwrap() error {
+	return dw.Err
+}
+
+// LenientGradebook is like Gradebook, but an attribute which cannot be parsed
+// does not fail the request. Instead, a DecodeWarning is returned for each such
+// attribute alongside the rest of the gradebook.
+func (c *Client) LenientGradebook() (*Gradebook, []DecodeWarning, error) {
+	return c.LenientGradebookForPeriodContext(context.Background(), -1)
+}
+
+// LenientGradebookContext is like LenientGradebook, but aborts the request when
+// ctx is cancelled or its deadline passes.
+func (c *Client) LenientGradebookContext(ctx context.Context) (*Gradebook, []DecodeWarning, error) {
+	return c.LenientGradebookForPeriodContext(ctx, -1)
+}
+
+// LenientGradebookForPeriod is like GradebookForPeriod, but decodes the
+// gradebook leniently as LenientGradebook does.
+func (c *Client) LenientGradebookForPeriod(gradingPeriodIndex int) (*Gradebook, []DecodeWarning, error) {
+	return c.LenientGradebookForPeriodContext(context.Background(), gradingPeriodIndex)
+}
+
+// LenientGradebookForPeriodContext is like LenientGradebookForPeriod, but aborts
+// the request when ctx is cancelled or its deadline passes.
+func (c *Client) LenientGradebookForPeriodContext(ctx context.Context, gradingPeriodIndex int) (*Gradebook, []DecodeWarning, error) {
+	raw := new(rawGradebook)
+
+	if err := c.invoke(ctx, c.gradebookRequest(gradingPeriodIndex), "Gradebook", raw); err != nil {
+		return nil, nil, err
+	}
+
+	gb, warnings := raw.convert()
+	localize(gb, c.location)
+	setCurrentMarks(gb)
+
+	return gb, warnings, nil
+}
+
+// The raw types mirror the gradebook's model, but hold every attribute which
+// must be parsed as a string, so that decoding them cannot fail.
+type rawGradebook struct {
+	XMLName              xml.Name            `xml:"Gradebook"`
+	GradingPeriods       []*rawGradingPeriod `xml:"ReportingPeriods>ReportPeriod"`
+	CurrentGradingPeriod *rawGradingPeriod   `xml:"ReportingPeriod"`
+	Courses              []*rawCourse        `xml:"Courses>Course"`
+}
+
+type rawGradingPeriod struct {
+	Index     string `xml:",attr"`
+	Name      string `xml:"GradePeriod,attr"`
+	StartDate string `xml:",attr"`
+	EndDate   string `xml:",attr"`
+}
+
+type rawCourse struct {
+	Period       string           `xml:",attr"`
+	Title        string           `xml:",attr"`
+	Room         string           `xml:",attr"`
+	Teacher      string           `xml:"Staff,attr"`
+	TeacherEmail string           `xml:"StaffEMail,attr"`
+	Marks        []*rawCourseMark `xml:"Marks>Mark"`
+}
+
+type rawCourseMark struct {
+	Name           string                    `xml:"MarkName,attr"`
+	LetterGrade    string                    `xml:"CalculatedScoreString,attr"`
+	RawGradeScore  string                    `xml:"CalculatedScoreRaw,attr"`
+	GradeSummaries []*rawAssignmentGradeCalc `xml:"GradeCalculationSummary>AssignmentGradeCalc"`
+	Assignments    []*rawAssignment          `xml:"Assignments>Assignment"`
+}
+
+type rawAssignmentGradeCalc struct {
+	Type               string `xml:",attr"`
+	Weight             string `xml:",attr"`
+	Points             string `xml:",attr"`
+	PointsPossible     string `xml:",attr"`
+	WeightedPercentage string `xml:"WeightedPct,attr"`
+	LetterGrade        string `xml:"CalculatedMark,attr"`
+}
+
+type rawAssignment struct {
+	GradebookID string `xml:",attr"`
+	Name        string `xml:"Measure,attr"`
+	Type        string `xml:",attr"`
+	Date        string `xml:",attr"`
+	DueDate     string `xml:",attr"`
+	Score       string `xml:",attr"`
+	ScoreType   string `xml:",attr"`
+	Points      string `xml:",attr"`
+	Notes       string `xml:",attr"`
+}
+
+// A lenientParser parses the attributes of a rawGradebook, collecting a
+// DecodeWarning for each value which cannot be parsed. Empty values of optional
+// attributes are parsed as their zero values without a warning.
+type lenientParser struct {
+	course, assignment string
+	warnings           []DecodeWarning
+}
+
+func (rg *rawGradebook) convert() (*Gradebook, []DecodeWarning) {
+	lp := new(lenientParser)
+	gb := &Gradebook{XMLName: rg.XMLName}
+
+	for _, gp := range rg.GradingPeriods {
+		gb.GradingPeriods = append(gb.GradingPeriods, lp.convertGradingPeriod(gp))
+	}
+
+	if rg.CurrentGradingPeriod != nil {
+		gb.CurrentGradingPeriod = lp.convertGradingPeriod(rg.CurrentGradingPeriod)
+	}
+
+	for _, rc := range rg.Courses {
+		gb.Courses = append(gb.Courses, lp.convertCourse(rc))
+	}
+
+	return gb, lp.warnings
+}
+
+func (lp *lenientParser) convertGradingPeriod(rgp *rawGradingPeriod) *GradingPeriod {
+	return &GradingPeriod{
+		Index:     lp.int("Index", rgp.Index),
+		Name:      rgp.Name,
+		StartDate: lp.date("StartDate", rgp.StartDate),
+		EndDate:   lp.date("EndDate", rgp.EndDate),
+	}
+}
+
+func (lp *lenientParser) convertCourse(rc *rawCourse) *Course {
+	lp.course, lp.assignment = rc.Title, ""
+	defer func() { lp.course = "" }()
+
+	c := &Course{
+		Period:       lp.int("Period", rc.Period),
+		ID:           lp.courseID("Title", rc.Title),
+		Room:         rc.Room,
+		Teacher:      rc.Teacher,
+		TeacherEmail: rc.TeacherEmail,
+	}
+
+	for _, rm := range rc.Marks {
+		cm := &CourseMark{
+			Name:          rm.Name,
+			LetterGrade:   rm.LetterGrade,
+			RawGradeScore: lp.float("CalculatedScoreRaw", rm.RawGradeScore),
+		}
+
+		for _, rgc := range rm.GradeSummaries {
+			cm.GradeSummaries = append(cm.GradeSummaries, &AssignmentGradeCalc{
+				Type:               rgc.Type,
+				Weight:             lp.percentage("Weight", rgc.Weight),
+				Points:             lp.float("Points", rgc.Points),
+				PointsPossible:     lp.float("PointsPossible", rgc.PointsPossible),
+				WeightedPercentage: lp.percentage("WeightedPct", rgc.WeightedPercentage),
+				LetterGrade:        rgc.LetterGrade,
+			})
+		}
+
+		for _, ra := range rm.Assignments {
+			cm.Assignments = append(cm.Assignments, lp.convertAssignment(ra))
+		}
+
+		c.Marks = append(c.Marks, cm)
+	}
+
+	return c
+}
+
+func (lp *lenientParser) convertAssignment(ra *rawAssignment) *Assignment {
+	lp.assignment = ra.Name
+	defer func() { lp.assignment = "" }()
+
+	return &Assignment{
+		GradebookID: ra.GradebookID,
+		Name:        ra.Name,
+		Type:        ra.Type,
+		Date:        lp.date("Date", ra.Date),
+		DueDate:     lp.date("DueDate", ra.DueDate),
+		Score:       lp.score("Score", ra.Score),
+		ScoreType:   ra.ScoreType,
+		Points:      lp.points("Points", ra.Points),
+		Notes:       ra.Notes,
+	}
+}
+
+func (lp *lenientParser) warn(attr, value string, err error) {
+	lp.warnings = append(lp.warnings, DecodeWarning{
+		Course:     lp.course,
+		Assignment: lp.assignment,
+		Attribute:  attr,
+		Value:      value,
+		Err:        err,
+	})
+}
+
+func (lp *lenientParser) int(attr, s string) int {
+	if strings.TrimSpace(s) == "" {
+		return 0
+	}
+
+	i, err := strconv.Atoi(strings.TrimSpace(s))
+
+	if err != nil {
+		lp.warn(attr, s, err)
+	}
+
+	return i
+}
+
+func (lp *lenientParser) float(attr, s string) float64 {
+	if strings.TrimSpace(s) == "" {
+		return 0
+	}
+
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+
+	if err != nil {
+		lp.warn(attr, s, err)
+
+		return 0
+	}
+
+	return f
+}
+
+func (lp *lenientParser) date(attr, s string) GradebookDate {
+	if strings.TrimSpace(s) == "" {
+		return GradebookDate{}
+	}
+
+	gd, err := parseGradebookDate(s)
+
+	if err != nil {
+		lp.warn(attr, s, err)
+	}
+
+	return gd
+}
+
+func (lp *lenientParser) percentage(attr, s string) Percentage {
+	if strings.TrimSpace(s) == "" {
+		return Percentage{}
+	}
+
+	p, err := parsePercentage(s)
+
+	if err != nil {
+		lp.warn(attr, s, err)
+	}
+
+	return p
+}
+
+// courseID falls back to a CourseID holding the whole title as its Name.
+func (lp *lenientParser) courseID(attr, s string) CourseID {
+	if s == "" {
+		return CourseID{}
+	}
+
+	cid, err := parseCourseID(s)
+
+	if err != nil {
+		lp.warn(attr, s, err)
+
+		return CourseID{Name: s}
+	}
+
+	return cid
+}
+
+func (lp *lenientParser) score(attr, s string) AssignmentScore {
+	as, err := parseAssignmentScore(s)
+
+	if err != nil {
+		lp.warn(attr, s, err)
+
+		return AssignmentScore{Raw: s}
+	}
+
+	return as
+}
+
+func (lp *lenientParser) points(attr, s string) AssignmentPoints {
+	if strings.TrimSpace(s) == "" {
+		return AssignmentPoints{}
+	}
+
+	ap, err := parseAssignmentPoints(s)
+
+	if err != nil {
+		lp.warn(attr, s, err)
+
+		return AssignmentPoints{Raw: s}
+	}
+
+	return ap
+}
@@ -0,0 +1,183 @@
+package govue_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jcorme/govue"
+	"github.com/jcorme/govue/govuetest"
+)
+
+// lenientGradebookDoc sets every attribute of the gradebook model, so that a
+// lenient decode which drops one differs from the strict decode.
+const lenientGradebookDoc = `<Gradebook>` +
+	`<ReportingPeriods><ReportPeriod Index="0" GradePeriod="Q1" StartDate="8/20/2024" EndDate="10/25/2024"/>` +
+	`<ReportPeriod Index="1" GradePeriod="Q2" StartDate="10/28/2024" EndDate="1/17/2025"/></ReportingPeriods>` +
+	`<ReportingPeriod Index="0" GradePeriod="Q1" StartDate="8/20/2024" EndDate="10/25/2024"/>` +
+	`<Courses><Course Period="1" Title="Algebra II (M201)" Room="12" Staff="Smith" StaffEMail="smith@example.com">` +
+	`<Marks><Mark MarkName="Q1" CalculatedScoreString="A" CalculatedScoreRaw="93.5">` +
+	`<GradeCalculationSummary><AssignmentGradeCalc Type="Quiz" Weight="40%" Points="17" PointsPossible="20" WeightedPct="34%" CalculatedMark="A-"/></GradeCalculationSummary>` +
+	`<Assignments>` +
+	`<Assignment GradebookID="7" Measure="Quiz 1" Type="Quiz" Date="9/1/2024" DueDate="9/2/2024" Score="9 out of 10" ScoreType="Raw Score" Points="9.00 / 10.00" Notes="Retake"/>` +
+	`<Assignment GradebookID="8" Measure="Quiz 2" Type="Quiz" Date="9/8/2024" DueDate="9/9/2024" Score="Excused" ScoreType="Raw Score" Points="10 Points Possible" Notes=""/>` +
+	`</Assignments></Mark></Marks></Course></Courses>` +
+	`</Gradebook>`
+
+func lenientClient(t *testing.T, doc string) *govue.Client {
+	t.Helper()
+
+	s := govuetest.NewServer()
+	t.Cleanup(s.Close)
+
+	s.AddUser("student", "secret", &govue.Student{Name: "Jane Doe"}).SetGradebook(-1, govuetest.Raw(doc))
+
+	return govue.NewClient("student", "secret", govue.WithEndpoint(s.Endpoint()))
+}
+
+func TestLenientGradebookMatchesGradebook(t *testing.T) {
+	c := lenientClient(t, lenientGradebookDoc)
+
+	want, err := c.Gradebook()
+
+	if err != nil {
+		t.Fatalf("Gradebook() error = %v", err)
+	}
+
+	got, warnings, err := c.LenientGradebook()
+
+	if err != nil {
+		t.Fatalf("LenientGradebook() error = %v", err)
+	}
+
+	if len(warnings) != 0 {
+		t.Errorf("LenientGradebook() warnings = %v, want none", warnings)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LenientGradebook() = %+v, want %+v as decoded by Gradebook", got, want)
+	}
+}
+
+func TestLenientGradebookWarnings(t *testing.T) {
+	tests := []struct {
+		name        string
+		old, new    string
+		want        govue.DecodeWarning
+		checkResult func(t *testing.T, gb *govue.Gradebook)
+	}{
+		{
+			name: "score",
+			old:  `Score="9 out of 10"`,
+			new:  `Score="nine"`,
+			want: govue.DecodeWarning{Course: "Algebra II (M201)", Assignment: "Quiz 1", Attribute: "Score", Value: "nine"},
+			checkResult: func(t *testing.T, gb *govue.Gradebook) {
+				a := gb.Courses[0].Marks[0].Assignments[0]
+
+				if a.Score.Status != govue.ScoreUnknown || a.Score.Raw != "nine" {
+					t.Errorf("Score = %+v, want an unknown score with Raw `nine`", a.Score)
+				}
+
+				if a.Points.Points != 9 || a.Notes != "Retake" {
+					t.Errorf("assignment = %+v, want its other attributes decoded", a)
+				}
+			},
+		},
+		{
+			name: "points",
+			old:  `Points="9.00 / 10.00"`,
+			new:  `Points="nine / ten"`,
+			want: govue.DecodeWarning{Course: "Algebra II (M201)", Assignment: "Quiz 1", Attribute: "Points", Value: "nine / ten"},
+			checkResult: func(t *testing.T, gb *govue.Gradebook) {
+				a := gb.Courses[0].Marks[0].Assignments[0]
+
+				if a.Points.Raw != "nine / ten" || a.Score.Score != 9 {
+					t.Errorf("assignment = %+v, want Points.Raw `nine / ten` and the score decoded", a)
+				}
+			},
+		},
+		{
+			name: "due date",
+			old:  `DueDate="9/9/2024"`,
+			new:  `DueDate="someday"`,
+			want: govue.DecodeWarning{Course: "Algebra II (M201)", Assignment: "Quiz 2", Attribute: "DueDate", Value: "someday"},
+			checkResult: func(t *testing.T, gb *govue.Gradebook) {
+				if a := gb.Courses[0].Marks[0].Assignments[1]; !a.DueDate.IsZero() || a.Date.IsZero() {
+					t.Errorf("assignment = %+v, want a zero DueDate and its Date decoded", a)
+				}
+			},
+		},
+		{
+			name: "grade calculation weight",
+			old:  `Weight="40%"`,
+			new:  `Weight="heavy"`,
+			want: govue.DecodeWarning{Course: "Algebra II (M201)", Attribute: "Weight", Value: "heavy"},
+			checkResult: func(t *testing.T, gb *govue.Gradebook) {
+				if gc := gb.Courses[0].Marks[0].GradeSummaries[0]; gc.Points != 17 {
+					t.Errorf("grade calculation = %+v, want its other attributes decoded", gc)
+				}
+			},
+		},
+		{
+			name: "course period",
+			old:  `Period="1"`,
+			new:  `Period="first"`,
+			want: govue.DecodeWarning{Course: "Algebra II (M201)", Attribute: "Period", Value: "first"},
+			checkResult: func(t *testing.T, gb *govue.Gradebook) {
+				if c := gb.Courses[0]; c.Period != 0 || c.ID.ID != "M201" || c.CurrentMark == nil {
+					t.Errorf("course = %+v, want period 0 and the rest decoded", c)
+				}
+			},
+		},
+		{
+			name: "grading period index",
+			old:  `Index="1"`,
+			new:  `Index="second"`,
+			want: govue.DecodeWarning{Attribute: "Index", Value: "second"},
+			checkResult: func(t *testing.T, gb *govue.Gradebook) {
+				if gp := gb.GradingPeriods[1]; gp.Name != "Q2" || gp.EndDate.IsZero() {
+					t.Errorf("grading period = %+v, want its other attributes decoded", gp)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(lenientGradebookDoc, tt.old) {
+				t.Fatalf("gradebook document has no %s", tt.old)
+			}
+
+			c := lenientClient(t, strings.Replace(lenientGradebookDoc, tt.old, tt.new, 1))
+
+			if _, err := c.Gradebook(); !errors.Is(err, govue.ErrDecode) {
+				t.Errorf("Gradebook() error = %v, want a decoding error", err)
+			}
+
+			gb, warnings, err := c.LenientGradebook()
+
+			if err != nil {
+				t.Fatalf("LenientGradebook() error = %v", err)
+			}
+
+			if len(warnings) != 1 {
+				t.Fatalf("LenientGradebook() warnings = %v, want 1", warnings)
+			}
+
+			w := warnings[0]
+
+			if w.Err == nil || !strings.Contains(w.Error(), tt.new) {
+				t.Errorf("warning = %v, want an error naming %s", w, tt.new)
+			}
+
+			w.Err = nil
+
+			if w != tt.want {
+				t.Errorf("warning = %+v, want %+v", w, tt.want)
+			}
+
+			tt.checkResult(t, gb)
+		})
+	}
+}
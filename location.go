@@ -0,0 +1,63 @@
+package govue
+
+import (
+	"reflect"
+	"time"
+)
+
+// WithLocation sets the time zone of the district's StudentVUE server, which
+// sends dates as wall-clock times without a time zone. Every GradebookDate
+// returned by the Client is anchored to loc; e.g. an assignment due on 9/2/2024
+// is due at midnight on September 2nd in loc rather than in UTC. Without this
+// option, dates are returned in UTC.
+func WithLocation(loc *time.Location) ClientOption {
+	return func(c *Client) {
+		c.location = loc
+	}
+}
+
+var gradebookDateType = reflect.TypeOf(GradebookDate{})
+
+// localize anchors every GradebookDate reachable from v, which must be a
+// pointer, to loc. A nil loc leaves v unchanged.
+func localize(v interface{}, loc *time.Location) {
+	if v == nil || loc == nil {
+		return
+	}
+
+	localizeValue(reflect.ValueOf(v), loc, make(map[uintptr]bool))
+}
+
+func localizeValue(rv reflect.Value, loc *time.Location, seen map[uintptr]bool) {
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() || seen[rv.Pointer()] {
+			return
+		}
+
+		seen[rv.Pointer()] = true
+		localizeValue(rv.Elem(), loc, seen)
+	case reflect.Interface:
+		if !rv.IsNil() {
+			localizeValue(rv.Elem(), loc, seen)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			localizeValue(rv.Index(i), loc, seen)
+		}
+	case reflect.Struct:
+		if rv.Type() == gradebookDateType {
+			if rv.CanSet() {
+				rv.Set(reflect.ValueOf(rv.Interface().(GradebookDate).in(loc)))
+			}
+
+			return
+		}
+
+		for i := 0; i < rv.NumField(); i++ {
+			if rv.Type().Field(i).IsExported() {
+				localizeValue(rv.Field(i), loc, seen)
+			}
+		}
+	}
+}
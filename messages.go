@@ -0,0 +1,139 @@
+package govue
+
+import "context"
+
+// A Message is a message sent to the student through StudentVUE, usually by
+// one of their teachers or by the school's staff.
+type Message struct {
+	// ID is StudentVUE's internal ID for the message.
+	ID string `xml:",attr"`
+
+	// Type is the kind of the message; e.g. `StudentActivity` or `Synergy Mail`.
+	Type string `xml:",attr"`
+
+	// Date is when the message was sent.
+	Date GradebookDate `xml:"BeginDate,attr"`
+
+	// From is the name of the message's sender.
+	From string `xml:",attr"`
+
+	// FromEmail is the email of the message's sender.
+	FromEmail string `xml:"EMail,attr"`
+
+	// Subject is the message's subject with any HTML removed.
+	Subject string `xml:"SubjectNoHTML,attr"`
+
+	// Body is the content of the message, which is usually HTML.
+	Body string `xml:"Content,attr"`
+
+	// Module is the part of StudentVUE from which the message was sent.
+	Module string `xml:",attr"`
+
+	// Read indicates whether the message has been read.
+	Read bool `xml:",attr"`
+
+	// Deletable indicates whether the message may be deleted by the student.
+	Deletable bool `xml:",attr"`
+
+	// Attachments holds the files attached to the message.
+	Attachments []*MessageAttachment `xml:"AttachmentDatas>AttachmentData"`
+}
+
+// A MessageAttachment is a file attached to a Message.
+type MessageAttachment struct {
+	// Name is the name of the attached file.
+	Name string `xml:"AttachmentName,attr"`
+
+	// AttachmentGU is StudentVUE's internal ID for the attachment.
+	AttachmentGU string `xml:"SmAttachmentGU,attr"`
+}
+
+// Messages returns the messages in the student's StudentVUE inbox.
+func (c *Client) Messages() ([]*Message, error) {
+	return c.MessagesContext(context.Background())
+}
+
+// MessagesContext is like Messages, but aborts the request when ctx is
+// cancelled or its deadline passes.
+func (c *Client) MessagesContext(ctx context.Context) ([]*Message, error) {
+	resp := new(struct {
+		Messages []*Message `xml:"MessageListings>MessageListing"`
+	})
+
+	err := c.invoke(ctx, svueRequest{
+		methodName:   "GetPXPMessages",
+		params:       c.childParams(),
+		skipLoginLog: true,
+	}, "PXPMessagesData", resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Messages, nil
+}
+
+// SynergyMail returns the messages in the student's Synergy Mail inbox, which
+// holds mail sent by teachers through the web portal.
+func (c *Client) SynergyMail() ([]*Message, error) {
+	return c.SynergyMailContext(context.Background())
+}
+
+// SynergyMailContext is like SynergyMail, but aborts the request when ctx is
+// cancelled or its deadline passes.
+func (c *Client) SynergyMailContext(ctx context.Context) ([]*Message, error) {
+	resp := new(struct {
+		Messages []*Message `xml:"MessageListings>MessageListing"`
+	})
+
+	err := c.invoke(ctx, svueRequest{
+		methodName:   "SynergyMailGetData",
+		params:       c.childParams(),
+		skipLoginLog: true,
+	}, "SynergyMailDataXML", resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Messages, nil
+}
+
+// MarkMessageRead marks the message as read in StudentVUE and sets its Read field.
+func (c *Client) MarkMessageRead(m *Message) error {
+	return c.MarkMessageReadContext(context.Background(), m)
+}
+
+// MarkMessageReadContext is like MarkMessageRead, but aborts the request when
+// ctx is cancelled or its deadline passes.
+func (c *Client) MarkMessageReadContext(ctx context.Context, m *Message) error {
+	err := c.invoke(ctx, svueRequest{
+		methodName: "UpdatePXPMessage",
+		params: &updateMessageParams{
+			Message: updateMessageListing{
+				ID:         m.ID,
+				Type:       m.Type,
+				MarkAsRead: true,
+			},
+		},
+		skipLoginLog: true,
+	}, "", nil)
+
+	if err != nil {
+		return err
+	}
+
+	m.Read = true
+
+	return nil
+}
+
+type updateMessageParams struct {
+	Message updateMessageListing `xml:"MessageListing"`
+}
+
+type updateMessageListing struct {
+	ID         string `xml:",attr"`
+	Type       string `xml:",attr"`
+	MarkAsRead bool   `xml:",attr"`
+}
@@ -0,0 +1,318 @@
+package govue
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// A RateLimit configures how requests to a single district's server are paced.
+type RateLimit struct {
+	// RequestsPerSecond is the rate at which requests may be sent. Zero means
+	// requests are not rate limited.
+	RequestsPerSecond float64
+
+	// Burst is the number of requests which may be sent at once after a quiet
+	// period. Values below 1 are treated as 1.
+	Burst int
+
+	// MaxInFlight is the maximum number of requests which may be awaiting a
+	// response at once. Zero means no maximum.
+	MaxInFlight int
+}
+
+// RateLimitStats holds metrics about the requests sent to a single host.
+type RateLimitStats struct {
+	// Requests is the number of requests which were let through.
+	Requests int64
+
+	// Delayed is the number of requests which had to wait before being sent.
+	Delayed int64
+
+	// TotalWait is the total time that requests spent waiting.
+	TotalWait time.Duration
+
+	// MaxWait is the longest time that a single request spent waiting.
+	MaxWait time.Duration
+
+	// InFlight is the number of requests currently awaiting a response.
+	InFlight int
+}
+
+// A RateLimiter paces the requests sent to each district's server, keyed by the
+// host of the Client's endpoint. Each host gets a token bucket, which limits the
+// rate of requests, and a cap on the number of requests in flight. A single
+// RateLimiter may be shared by many Clients so that their requests to the same
+// district are paced together.
+type RateLimiter struct {
+	mu           sync.Mutex
+	defaultLimit RateLimit
+	limits       map[string]RateLimit
+	hosts        map[string]*hostLimiter
+}
+
+// NewRateLimiter returns a RateLimiter which applies defaultLimit to every host
+// without a limit of its own.
+func NewRateLimiter(defaultLimit RateLimit) *RateLimiter {
+	return &RateLimiter{
+		defaultLimit: defaultLimit,
+		limits:       make(map[string]RateLimit),
+		hosts:        make(map[string]*hostLimiter),
+	}
+}
+
+// WithRateLimiter makes every request sent by the Client, including retries,
+// wait for permission from rl.
+func WithRateLimiter(rl *RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = rl
+	}
+}
+
+// SetLimit sets the limit for the district server with the given host; e.g.
+// `district.edupoint.com`.
+func (rl *RateLimiter) SetLimit(host string, limit RateLimit) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.limits[host] = limit
+
+	if hl, ok := rl.hosts[host]; ok {
+		hl.setLimit(limit)
+	}
+}
+
+// Stats returns the metrics for the given host.
+func (rl *RateLimiter) Stats(host string) RateLimitStats {
+	rl.mu.Lock()
+	hl, ok := rl.hosts[host]
+	rl.mu.Unlock()
+
+	if !ok {
+		return RateLimitStats{}
+	}
+
+	return hl.snapshot()
+}
+
+// AllStats returns the metrics for every host to which requests have been sent.
+func (rl *RateLimiter) AllStats() map[string]RateLimitStats {
+	rl.mu.Lock()
+	hosts := make(map[string]*hostLimiter, len(rl.hosts))
+
+	for h, hl := range rl.hosts {
+		hosts[h] = hl
+	}
+
+	rl.mu.Unlock()
+
+	stats := make(map[string]RateLimitStats, len(hosts))
+
+	for h, hl := range hosts {
+		stats[h] = hl.snapshot()
+	}
+
+	return stats
+}
+
+// wait blocks until a request may be sent to the endpoint, or ctx is done. The
+// returned function must be called once the request's response has been read.
+func (rl *RateLimiter) wait(ctx context.Context, endpoint string) (func(), error) {
+	host := endpoint
+
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	rl.mu.Lock()
+	hl, ok := rl.hosts[host]
+
+	if !ok {
+		limit, ok := rl.limits[host]
+
+		if !ok {
+			limit = rl.defaultLimit
+		}
+
+		hl = newHostLimiter(limit)
+		rl.hosts[host] = hl
+	}
+
+	rl.mu.Unlock()
+
+	return hl.acquire(ctx)
+}
+
+// A hostLimiter paces the requests to a single host. The number of requests in
+// flight is counted in stats.InFlight under mu, so that a change of MaxInFlight
+// applies to the requests already in flight.
+type hostLimiter struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	maxInFlight int
+	stats       RateLimitStats
+
+	// released is closed and replaced whenever a request in flight finishes or
+	// the limit changes, to wake the requests waiting for a slot.
+	released chan struct{}
+}
+
+func newHostLimiter(limit RateLimit) *hostLimiter {
+	hl := &hostLimiter{last: time.Now(), released: make(chan struct{})}
+	hl.setLimit(limit)
+	hl.tokens = hl.burst
+
+	return hl
+}
+
+func (hl *hostLimiter) setLimit(limit RateLimit) {
+	hl.mu.Lock()
+	defer hl.mu.Unlock()
+
+	hl.rate = limit.RequestsPerSecond
+	hl.burst = float64(limit.Burst)
+
+	if hl.burst < 1 {
+		hl.burst = 1
+	}
+
+	if hl.tokens > hl.burst {
+		hl.tokens = hl.burst
+	}
+
+	hl.maxInFlight = limit.MaxInFlight
+	hl.wake()
+}
+
+// wake wakes the requests waiting for a slot. hl.mu must be held.
+func (hl *hostLimiter) wake() {
+	close(hl.released)
+	hl.released = make(chan struct{})
+}
+
+func (hl *hostLimiter) acquire(ctx context.Context) (func(), error) {
+	start := time.Now()
+
+	if err := hl.take(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := hl.enter(ctx); err != nil {
+		hl.refund()
+
+		return nil, err
+	}
+
+	waited := time.Since(start)
+
+	hl.mu.Lock()
+	hl.stats.Requests++
+	hl.stats.TotalWait += waited
+
+	if waited > time.Millisecond {
+		hl.stats.Delayed++
+	}
+
+	if waited > hl.stats.MaxWait {
+		hl.stats.MaxWait = waited
+	}
+
+	hl.mu.Unlock()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			hl.mu.Lock()
+			hl.stats.InFlight--
+			hl.wake()
+			hl.mu.Unlock()
+		})
+	}, nil
+}
+
+// enter waits until fewer than MaxInFlight requests are in flight, then counts
+// the request as in flight.
+func (hl *hostLimiter) enter(ctx context.Context) error {
+	for {
+		hl.mu.Lock()
+
+		if hl.maxInFlight <= 0 || hl.stats.InFlight < hl.maxInFlight {
+			hl.stats.InFlight++
+			hl.mu.Unlock()
+
+			return nil
+		}
+
+		released := hl.released
+		hl.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// take reserves a token from the bucket, waiting for it to be refilled if
+// necessary. Reservations are handed out in order, so waiting requests are
+// served first come, first served.
+func (hl *hostLimiter) take(ctx context.Context) error {
+	hl.mu.Lock()
+
+	if hl.rate <= 0 {
+		hl.mu.Unlock()
+
+		return nil
+	}
+
+	now := time.Now()
+	hl.tokens += now.Sub(hl.last).Seconds() * hl.rate
+	hl.last = now
+
+	if hl.tokens > hl.burst {
+		hl.tokens = hl.burst
+	}
+
+	hl.tokens--
+	wait := time.Duration(-hl.tokens / hl.rate * float64(time.Second))
+	hl.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(wait)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		hl.refund()
+
+		return ctx.Err()
+	}
+}
+
+// refund returns the token reserved by take for a request which was not sent.
+func (hl *hostLimiter) refund() {
+	hl.mu.Lock()
+	defer hl.mu.Unlock()
+
+	if hl.rate > 0 {
+		hl.tokens++
+	}
+}
+
+func (hl *hostLimiter) snapshot() RateLimitStats {
+	hl.mu.Lock()
+	defer hl.mu.Unlock()
+
+	return hl.stats
+}
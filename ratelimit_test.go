@@ -0,0 +1,97 @@
+package govue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+const rateLimitTestEndpoint = "https://district.example.com/Service/PXPCommunication.asmx"
+
+func TestRateLimiterSetLimitKeepsRequestsInFlight(t *testing.T) {
+	rl := NewRateLimiter(RateLimit{MaxInFlight: 1})
+
+	release, err := rl.wait(context.Background(), rateLimitTestEndpoint)
+
+	if err != nil {
+		t.Fatalf("wait() error = %v", err)
+	}
+
+	rl.SetLimit("district.example.com", RateLimit{MaxInFlight: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := rl.wait(ctx, rateLimitTestEndpoint); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("wait() with a request in flight error = %v, want the deadline to pass", err)
+	}
+
+	rl.SetLimit("district.example.com", RateLimit{MaxInFlight: 2})
+
+	release2, err := rl.wait(context.Background(), rateLimitTestEndpoint)
+
+	if err != nil {
+		t.Fatalf("wait() after raising MaxInFlight error = %v", err)
+	}
+
+	if n := rl.Stats("district.example.com").InFlight; n != 2 {
+		t.Errorf("InFlight = %d, want 2", n)
+	}
+
+	release()
+	release2()
+
+	if n := rl.Stats("district.example.com").InFlight; n != 0 {
+		t.Errorf("InFlight after release = %d, want 0", n)
+	}
+}
+
+func TestRateLimiterWakesWaitingRequest(t *testing.T) {
+	rl := NewRateLimiter(RateLimit{MaxInFlight: 1})
+
+	release, err := rl.wait(context.Background(), rateLimitTestEndpoint)
+
+	if err != nil {
+		t.Fatalf("wait() error = %v", err)
+	}
+
+	time.AfterFunc(10*time.Millisecond, release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := rl.wait(ctx, rateLimitTestEndpoint); err != nil {
+		t.Errorf("wait() after the request in flight finished error = %v", err)
+	}
+}
+
+func TestRateLimiterRefundsTokenWhenCancelled(t *testing.T) {
+	rl := NewRateLimiter(RateLimit{RequestsPerSecond: 1, Burst: 2, MaxInFlight: 1})
+
+	release, err := rl.wait(context.Background(), rateLimitTestEndpoint)
+
+	if err != nil {
+		t.Fatalf("wait() error = %v", err)
+	}
+
+	// The second request takes the last token, then gives up waiting for a slot.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := rl.wait(ctx, rateLimitTestEndpoint); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("wait() with a request in flight error = %v, want the deadline to pass", err)
+	}
+
+	release()
+
+	start := time.Now()
+
+	if _, err := rl.wait(context.Background(), rateLimitTestEndpoint); err != nil {
+		t.Fatalf("wait() error = %v", err)
+	}
+
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("wait() took %s, want the cancelled request's token to be returned", d)
+	}
+}
@@ -0,0 +1,205 @@
+package govue
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"reflect"
+	"time"
+)
+
+// A RetryPolicy decides whether and when a failed request is retried. Requests
+// are never retried when the credentials are invalid or the account is locked,
+// nor once the request's context is done.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is attempted,
+	// including the first attempt. Values below 2 disable retrying.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay before any retry. Zero means no cap.
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor by which the delay grows after each retry.
+	// Values below 1 are treated as 1.
+	Multiplier float64
+
+	// Jitter is the fraction, between 0 and 1, of each delay that is randomized
+	// so that many clients do not retry in lockstep; e.g. a Jitter of 0.2 picks
+	// a delay between 80% and 100% of the computed backoff.
+	Jitter float64
+
+	// RetryableCodes holds the SVUEError codes for which a request is retried.
+	RetryableCodes []int
+
+	// RetryableStatuses holds the HTTP status codes for which a request is
+	// retried, whether or not the response carries a SOAP fault.
+	RetryableStatuses []int
+
+	// RetryNetworkErrors enables retrying requests which fail with a network
+	// error, such as a timeout or a refused or reset connection, whether before
+	// the response is received or while it is read.
+	RetryNetworkErrors bool
+
+	// OnAttempt, if set, is called after every attempt of a request.
+	OnAttempt func(RetryAttempt)
+}
+
+// A RetryAttempt describes a single attempt of a request made under a RetryPolicy.
+type RetryAttempt struct {
+	// Method is the name of the StudentVUE method which was called.
+	Method string
+
+	// Attempt is the one-based number of the attempt.
+	Attempt int
+
+	// Err is the error with which the attempt failed, or nil if it succeeded.
+	Err error
+
+	// Retrying indicates whether the request will be attempted again.
+	Retrying bool
+
+	// Delay is how long the Client waits before the next attempt.
+	Delay time.Duration
+}
+
+// DefaultRetryPolicy returns a RetryPolicy which makes up to three attempts,
+// retrying network errors and the HTTP statuses which usually indicate an
+// overloaded server, including SOAP faults sent with them. RT_ERROR results are
+// not retried, since the server sends them for requests which would fail again.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:        3,
+		InitialBackoff:     500 * time.Millisecond,
+		MaxBackoff:         10 * time.Second,
+		Multiplier:         2,
+		Jitter:             0.2,
+		RetryableStatuses:  []int{429, 500, 502, 503, 504},
+		RetryNetworkErrors: true,
+	}
+}
+
+// WithRetryPolicy makes the Client retry failed requests according to p.
+// Requests are not retried by default.
+func WithRetryPolicy(p *RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = p
+	}
+}
+
+// retryable reports whether a request made with ctx which failed with err may be
+// retried. Whether to give up is decided by ctx rather than by err, since a
+// timeout of the HTTP client, such as the one set by WithTimeout, also matches
+// context.DeadlineExceeded.
+func (p *RetryPolicy) retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var (
+		sErr SVUEError
+		fErr SOAPFaultError
+		hErr HTTPStatusError
+		nErr net.Error
+	)
+
+	switch {
+	case errors.As(err, &nErr):
+		// A network error may also be wrapped in an SVUEError with the code
+		// DecodingError, if the connection failed while the response was read.
+		return p.RetryNetworkErrors
+	case errors.As(err, &sErr):
+		if sErr.Code == InvalidCredentialsError || sErr.Code == AccountLockedError {
+			return false
+		}
+
+		return containsInt(p.RetryableCodes, sErr.Code)
+	case errors.As(err, &fErr):
+		return containsInt(p.RetryableStatuses, fErr.StatusCode)
+	case errors.As(err, &hErr):
+		return containsInt(p.RetryableStatuses, hErr.StatusCode)
+	default:
+		return false
+	}
+}
+
+// backoff returns the delay before the retry following the given attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	mult := math.Max(p.Multiplier, 1)
+	d := float64(p.InitialBackoff) * math.Pow(mult, float64(attempt-1))
+
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+
+	if j := math.Min(math.Max(p.Jitter, 0), 1); j > 0 {
+		d -= d * j * rand.Float64()
+	}
+
+	return time.Duration(d)
+}
+
+// retry calls attempt until it succeeds or the Client's RetryPolicy gives up.
+// v, the value into which attempt decodes, is reset between attempts if it is a
+// non-nil pointer; any other v is left for the decoder to reject.
+func (c *Client) retry(ctx context.Context, method string, v interface{}, attempt func() error) error {
+	p := c.retryPolicy
+
+	if p == nil || p.MaxAttempts < 2 {
+		return attempt()
+	}
+
+	for n := 1; ; n++ {
+		if rv := reflect.ValueOf(v); n > 1 && rv.Kind() == reflect.Ptr && !rv.IsNil() {
+			rv = rv.Elem()
+			rv.Set(reflect.Zero(rv.Type()))
+		}
+
+		err := attempt()
+		retrying := err != nil && n < p.MaxAttempts && p.retryable(ctx, err)
+
+		var delay time.Duration
+
+		if retrying {
+			delay = p.backoff(n)
+		}
+
+		if p.OnAttempt != nil {
+			p.OnAttempt(RetryAttempt{
+				Method:   method,
+				Attempt:  n,
+				Err:      err,
+				Retrying: retrying,
+				Delay:    delay,
+			})
+		}
+
+		if !retrying {
+			return err
+		}
+
+		t := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			t.Stop()
+
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+func containsInt(is []int, i int) bool {
+	for _, j := range is {
+		if i == j {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,236 @@
+package govue_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jcorme/govue"
+	"github.com/jcorme/govue/govuetest"
+)
+
+func testRetryPolicy() *govue.RetryPolicy {
+	p := govue.DefaultRetryPolicy()
+	p.InitialBackoff = time.Millisecond
+	p.MaxBackoff = time.Millisecond
+
+	return p
+}
+
+func newRetryingClient(endpoint string, p *govue.RetryPolicy, opts ...govue.ClientOption) *govue.Client {
+	opts = append([]govue.ClientOption{
+		govue.WithEndpoint(endpoint),
+		govue.WithRetryPolicy(p),
+	}, opts...)
+
+	return govue.NewClient("student", "secret", opts...)
+}
+
+// attemptRecorder collects the RetryAttempts passed to a RetryPolicy's OnAttempt.
+type attemptRecorder struct {
+	mu       sync.Mutex
+	attempts []govue.RetryAttempt
+}
+
+func (ar *attemptRecorder) record(a govue.RetryAttempt) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	ar.attempts = append(ar.attempts, a)
+}
+
+func newTestServer(t *testing.T) *govuetest.Server {
+	t.Helper()
+
+	s := govuetest.NewServer()
+	t.Cleanup(s.Close)
+
+	s.AddUser("student", "secret", &govue.Student{Name: "Jane Doe"})
+
+	return s
+}
+
+func TestRetryAfterServiceUnavailable(t *testing.T) {
+	s := newTestServer(t)
+	s.Inject(govuetest.Injection{Times: 1, StatusCode: http.StatusServiceUnavailable})
+
+	st, err := newRetryingClient(s.Endpoint(), testRetryPolicy()).SignIn()
+
+	if err != nil {
+		t.Fatalf("SignIn() error = %v", err)
+	}
+
+	if st.Name != "Jane Doe" {
+		t.Errorf("SignIn() name = %q, want %q", st.Name, "Jane Doe")
+	}
+
+	if n := len(s.Requests()); n != 2 {
+		t.Errorf("server received %d requests, want 2", n)
+	}
+}
+
+func TestRetryWithNonPointerOut(t *testing.T) {
+	s := newTestServer(t)
+	s.Inject(govuetest.Injection{Times: 1, StatusCode: http.StatusServiceUnavailable})
+
+	var out struct {
+		Children []*govue.Student `xml:"Child"`
+	}
+
+	err := newRetryingClient(s.Endpoint(), testRetryPolicy()).Call(context.Background(), "ChildList", nil, out)
+
+	if !errors.Is(err, govue.ErrDecode) {
+		t.Errorf("Call() error = %v, want a decoding error", err)
+	}
+}
+
+func TestRetryOnAttempt(t *testing.T) {
+	s := newTestServer(t)
+	s.Inject(govuetest.Injection{Times: 2, StatusCode: http.StatusServiceUnavailable})
+
+	ar := new(attemptRecorder)
+	p := testRetryPolicy()
+	p.OnAttempt = ar.record
+
+	if _, err := newRetryingClient(s.Endpoint(), p).SignIn(); err != nil {
+		t.Fatalf("SignIn() error = %v", err)
+	}
+
+	if len(ar.attempts) != 3 {
+		t.Fatalf("OnAttempt called %d times, want 3", len(ar.attempts))
+	}
+
+	for i, a := range ar.attempts {
+		last := i == len(ar.attempts)-1
+
+		if a.Method != "ChildList" || a.Attempt != i+1 {
+			t.Errorf("attempt %d: Method, Attempt = %q, %d, want ChildList, %d", i+1, a.Method, a.Attempt, i+1)
+		}
+
+		if (a.Err == nil) != last || a.Retrying == last {
+			t.Errorf("attempt %d: Err, Retrying = %v, %t, want the request to be retried until it succeeds", i+1, a.Err, a.Retrying)
+		}
+
+		if (a.Delay > 0) == last {
+			t.Errorf("attempt %d: Delay = %s", i+1, a.Delay)
+		}
+	}
+
+	var hErr govue.HTTPStatusError
+
+	if !errors.As(ar.attempts[0].Err, &hErr) || hErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("attempt 1: Err = %v, want HTTP 503", ar.attempts[0].Err)
+	}
+}
+
+func TestRetryAfterClientTimeout(t *testing.T) {
+	s := newTestServer(t)
+	s.Inject(govuetest.Injection{Times: 2, Latency: time.Second})
+
+	ar := new(attemptRecorder)
+	p := testRetryPolicy()
+	p.OnAttempt = ar.record
+
+	c := newRetryingClient(s.Endpoint(), p, govue.WithTimeout(50*time.Millisecond))
+
+	if _, err := c.SignIn(); err != nil {
+		t.Fatalf("SignIn() error = %v", err)
+	}
+
+	if n := len(s.Requests()); n != 3 {
+		t.Errorf("server received %d requests, want 3", n)
+	}
+
+	if len(ar.attempts) != 3 || !errors.Is(ar.attempts[0].Err, context.DeadlineExceeded) {
+		t.Errorf("attempts = %+v, want two timeouts followed by a success", ar.attempts)
+	}
+}
+
+func TestRetryAfterTimeoutReadingBody(t *testing.T) {
+	s := newTestServer(t)
+
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+
+	// The first response stalls after the start of the envelope; later requests
+	// are passed on to s.
+	stall := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		first := calls == 1
+		mu.Unlock()
+
+		if !first {
+			s.Config.Handler.ServeHTTP(w, r)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?><soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>`))
+		w.(http.Flusher).Flush()
+
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer stall.Close()
+
+	ar := new(attemptRecorder)
+	p := testRetryPolicy()
+	p.OnAttempt = ar.record
+
+	c := newRetryingClient(stall.URL+govuetest.ServicePath, p, govue.WithTimeout(50*time.Millisecond))
+
+	if _, err := c.SignIn(); err != nil {
+		t.Fatalf("SignIn() error = %v", err)
+	}
+
+	if len(ar.attempts) != 2 || !errors.Is(ar.attempts[0].Err, govue.ErrDecode) {
+		t.Errorf("attempts = %+v, want a timeout while decoding followed by a success", ar.attempts)
+	}
+}
+
+func TestRetryStopsAtContextDeadline(t *testing.T) {
+	s := newTestServer(t)
+	s.Inject(govuetest.Injection{Latency: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := newRetryingClient(s.Endpoint(), testRetryPolicy()).SignInContext(ctx)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SignInContext() error = %v, want the deadline to pass", err)
+	}
+
+	if n := len(s.Requests()); n != 1 {
+		t.Errorf("server received %d requests, want 1", n)
+	}
+}
+
+func TestRetryDoesNotRetryResultErrors(t *testing.T) {
+	s := newTestServer(t)
+
+	var out struct{}
+
+	err := newRetryingClient(s.Endpoint(), testRetryPolicy()).Call(context.Background(), "Unsupported", nil, &out)
+
+	var sErr govue.SVUEError
+
+	if !errors.As(err, &sErr) || !errors.Is(err, govue.ErrUnexpected) || !strings.Contains(sErr.Message, "not supported") {
+		t.Errorf("Call() error = %v, want an unexpected error with the server's message", err)
+	}
+
+	if n := len(s.Requests()); n != 1 {
+		t.Errorf("server received %d requests, want 1", n)
+	}
+}
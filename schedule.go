@@ -0,0 +1,233 @@
+package govue
+
+import (
+	"context"
+	"encoding/xml"
+	"strings"
+	"time"
+)
+
+// A ClassSchedule holds a student's classes for a single term.
+type ClassSchedule struct {
+	XMLName xml.Name `xml:"StudentClassSchedule"`
+
+	// TermIndex is a zero-based index representing the schedule's term in the
+	// Terms set.
+	TermIndex int `xml:",attr"`
+
+	// TermName is the name of the schedule's term.
+	TermName string `xml:"TermIndexName,attr"`
+
+	// Classes holds the class sections in which the student is enrolled for the
+	// term, ordered by period.
+	Classes []*ClassSection `xml:"ClassLists>ClassListing"`
+
+	// Terms holds all of the school's terms for the school year.
+	Terms []*Term `xml:"TermLists>TermListing"`
+}
+
+// A Term is one of a school's scheduling terms; e.g. a semester or trimester.
+type Term struct {
+	// Index is a zero-based index representing the Term's place in the Terms set.
+	Index int `xml:"TermIndex,attr"`
+
+	// Code is the school's short code for the term; e.g. `S1`.
+	Code string `xml:"TermCode,attr"`
+
+	// Name is the name of the term.
+	Name string `xml:"TermName,attr"`
+
+	// StartDate is when the term begins.
+	StartDate GradebookDate `xml:"BeginDate,attr"`
+
+	// EndDate is when the term ends.
+	EndDate GradebookDate `xml:",attr"`
+}
+
+// A ClassSection is a single class in which a student is enrolled.
+type ClassSection struct {
+	// Period is the period of the day in which the student has this class.
+	Period int `xml:",attr"`
+
+	// Title is the name of the class. Some schools append the class's ID in
+	// parentheses, as in the Gradebook.
+	Title string `xml:"CourseTitle,attr"`
+
+	// Room is the room number of this class inside the school.
+	Room string `xml:"RoomName,attr"`
+
+	// Teacher is the name of the instructor of this class.
+	Teacher string `xml:",attr"`
+
+	// TeacherEmail is the email of this class's instructor.
+	TeacherEmail string `xml:",attr"`
+
+	// TeacherGU is StudentVUE's internal ID for this class's instructor.
+	TeacherGU string `xml:"TeacherStaffGU,attr"`
+
+	// SectionGU is StudentVUE's internal ID for this class section.
+	SectionGU string `xml:",attr"`
+
+	// StartTime is the time of day at which the class begins. It is only known
+	// for classes held on the current day and is zero otherwise.
+	StartTime ClassTime `xml:",attr"`
+
+	// EndTime is the time of day at which the class ends. It is only known for
+	// classes held on the current day and is zero otherwise.
+	EndTime ClassTime `xml:",attr"`
+}
+
+// CourseID returns the ID and name of the class, if the school includes the
+// class's ID in its Title.
+func (cs *ClassSection) CourseID() (CourseID, bool) {
+	id, err := parseCourseID(cs.Title)
+
+	if err != nil {
+		return CourseID{Name: cs.Title}, false
+	}
+
+	return id, true
+}
+
+// MatchCourses maps each of the schedule's class sections onto the course in gb
+// with the same CourseID.ID. Class sections whose titles do not carry an ID are
+// matched to the course held in the same period with the same name instead.
+// Sections without a matching course are left out of the map.
+func (s *ClassSchedule) MatchCourses(gb *Gradebook) map[*ClassSection]*Course {
+	matches := make(map[*ClassSection]*Course)
+
+	for _, cs := range s.Classes {
+		id, ok := cs.CourseID()
+
+		for _, c := range gb.Courses {
+			if ok && c.ID.ID == id.ID {
+				matches[cs] = c
+
+				break
+			}
+
+			if !ok && c.Period == cs.Period && strings.EqualFold(c.ID.Name, id.Name) {
+				matches[cs] = c
+
+				break
+			}
+		}
+	}
+
+	return matches
+}
+
+// A ClassTime holds a time of day parsed from the format of StudentVUE's systems.
+// Its date is always January 1, year 0.
+type ClassTime struct {
+	time.Time
+}
+
+func (ct *ClassTime) UnmarshalXMLAttr(attr xml.Attr) error {
+	const classTimeFormat = "3:04 PM"
+
+	if attr.Value == "" {
+		*ct = ClassTime{}
+
+		return nil
+	}
+
+	t, err := time.Parse(classTimeFormat, attr.Value)
+
+	if err != nil {
+		return err
+	}
+
+	*ct = ClassTime{t}
+
+	return nil
+}
+
+// ClassSchedule returns the student's class schedule for the term at the given
+// index of ClassSchedule.Terms. A negative index selects the current term.
+func (c *Client) ClassSchedule(termIndex int) (*ClassSchedule, error) {
+	return c.ClassScheduleContext(context.Background(), termIndex)
+}
+
+// ClassScheduleContext is like ClassSchedule, but aborts the request when ctx is
+// cancelled or its deadline passes.
+func (c *Client) ClassScheduleContext(ctx context.Context, termIndex int) (*ClassSchedule, error) {
+	params := &classListParams{ChildIntID: c.childIndex}
+
+	if termIndex >= 0 {
+		params.TermIndex = &termIndex
+	}
+
+	// The bell schedule of the current day is the only place StudentVUE gives
+	// the times at which classes begin and end.
+	resp := struct {
+		*ClassSchedule
+		TodayClasses []*struct {
+			SectionGU string    `xml:",attr"`
+			StartTime ClassTime `xml:",attr"`
+			EndTime   ClassTime `xml:",attr"`
+		} `xml:"TodayScheduleInfoData>SchoolInfos>SchoolInfo>Classes>ClassInfo"`
+	}{ClassSchedule: new(ClassSchedule)}
+
+	err := c.invoke(ctx, svueRequest{
+		methodName:   "StudentClassList",
+		params:       params,
+		skipLoginLog: true,
+	}, "StudentClassSchedule", &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tc := range resp.TodayClasses {
+		for _, cs := range resp.Classes {
+			if cs.SectionGU == tc.SectionGU {
+				cs.StartTime = tc.StartTime
+				cs.EndTime = tc.EndTime
+			}
+		}
+	}
+
+	return resp.ClassSchedule, nil
+}
+
+// ClassSchedules returns the student's class schedule for every term of the
+// school year, ordered by term index.
+func (c *Client) ClassSchedules() ([]*ClassSchedule, error) {
+	return c.ClassSchedulesContext(context.Background())
+}
+
+// ClassSchedulesContext is like ClassSchedules, but aborts the requests when ctx
+// is cancelled or its deadline passes.
+func (c *Client) ClassSchedulesContext(ctx context.Context) ([]*ClassSchedule, error) {
+	current, err := c.ClassScheduleContext(ctx, -1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make([]*ClassSchedule, 0, len(current.Terms))
+
+	for _, t := range current.Terms {
+		if t.Index == current.TermIndex {
+			schedules = append(schedules, current)
+
+			continue
+		}
+
+		s, err := c.ClassScheduleContext(ctx, t.Index)
+
+		if err != nil {
+			return nil, err
+		}
+
+		schedules = append(schedules, s)
+	}
+
+	return schedules, nil
+}
+
+type classListParams struct {
+	ChildIntID int
+	TermIndex  *int `xml:",omitempty"`
+}
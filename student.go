@@ -1,15 +1,176 @@
 package govue
 
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"strings"
+)
+
 type Student struct {
-	ID     int
-	Name   string `xml:"ChildName"`
-	School string `xml:"OrganizationName"`
-	Grade  int
-	Events []*Event `xml:"Events>Event"`
+	ID     int      `json:"id"`
+	Name   string   `xml:"ChildName" json:"name"`
+	School string   `xml:"OrganizationName" json:"school"`
+	Grade  int      `json:"grade"`
+	Events []*Event `xml:"Events>Event" json:"events"`
 }
 
 type Event struct {
-	Date        string `xml:"EventDate"`
-	Description string `xml:"EventDescription"`
-	Module      string
+	Date        string `xml:"EventDate" json:"date"`
+	Description string `xml:"EventDescription" json:"description"`
+	Module      string `json:"module"`
+}
+
+// A StudentProfile holds a student's full personal and contact information as
+// kept by their school.
+type StudentProfile struct {
+	XMLName xml.Name `xml:"StudentInfo"`
+
+	// PermID is the student's permanent ID within the district.
+	PermID string
+
+	// Name is the student's full name, formatted for display.
+	Name string `xml:"FormattedName"`
+
+	// LastNameGoesBy is the last name which the student prefers.
+	LastNameGoesBy string
+
+	// NickName is the student's nickname.
+	NickName string
+
+	// BirthDate is the student's date of birth.
+	BirthDate GradebookDate
+
+	// Gender is the student's gender as recorded by the school.
+	Gender string
+
+	// Grade is the student's grade level.
+	Grade string
+
+	// Address is the student's home address. Lines of the address are separated
+	// by newlines.
+	Address string
+
+	// Email is the student's email address.
+	Email string `xml:"EMail"`
+
+	// Phone is the student's home phone number.
+	Phone string
+
+	// HomeLanguage is the language spoken in the student's home.
+	HomeLanguage string
+
+	// School is the name of the school which the student currently attends.
+	School string `xml:"CurrentSchool"`
+
+	// Track is the student's track (schedule) within the school.
+	Track string
+
+	// HomeRoom is the room number of the student's home room.
+	HomeRoom string
+
+	// HomeRoomTeacher is the name of the student's home room teacher.
+	HomeRoomTeacher string `xml:"HomeRoomTch"`
+
+	// HomeRoomTeacherEmail is the email of the student's home room teacher.
+	HomeRoomTeacherEmail string `xml:"HomeRoomTchEMail"`
+
+	// Counselor is the name of the student's counselor.
+	Counselor string `xml:"CounselorName"`
+
+	// CounselorEmail is the email of the student's counselor.
+	CounselorEmail string
+
+	// EmergencyContacts holds the people to contact in case of an emergency.
+	EmergencyContacts []*EmergencyContact `xml:"EmergencyContacts>EmergencyContact"`
+
+	// Physician is the student's primary physician.
+	Physician *MedicalContact
+
+	// Dentist is the student's dentist.
+	Dentist *MedicalContact
+
+	// Photo holds the student's photo, usually a JPEG image.
+	Photo []byte `xml:"-"`
+}
+
+// An EmergencyContact is a person to contact in case of an emergency.
+type EmergencyContact struct {
+	// Name is the name of the contact.
+	Name string `xml:",attr"`
+
+	// Relationship is how the contact is related to the student; e.g. `Mother`.
+	Relationship string `xml:",attr"`
+
+	// HomePhone is the contact's home phone number.
+	HomePhone string `xml:",attr"`
+
+	// WorkPhone is the contact's work phone number.
+	WorkPhone string `xml:",attr"`
+
+	// MobilePhone is the contact's mobile phone number.
+	MobilePhone string `xml:",attr"`
+
+	// OtherPhone is any other phone number at which the contact may be reached.
+	OtherPhone string `xml:",attr"`
+}
+
+// A MedicalContact is a physician or dentist who treats the student.
+type MedicalContact struct {
+	// Name is the name of the physician or dentist.
+	Name string `xml:",attr"`
+
+	// Hospital is the hospital at which a physician practices.
+	Hospital string `xml:",attr"`
+
+	// Office is the office at which a dentist practices.
+	Office string `xml:",attr"`
+
+	// Phone is their phone number.
+	Phone string `xml:",attr"`
+
+	// Extension is their phone extension.
+	Extension string `xml:"Extn,attr"`
+}
+
+// StudentInfo returns the student's full profile.
+func (c *Client) StudentInfo() (*StudentProfile, error) {
+	return c.StudentInfoContext(context.Background())
+}
+
+// StudentInfoContext is like StudentInfo, but aborts the request when ctx is
+// cancelled or its deadline passes.
+func (c *Client) StudentInfoContext(ctx context.Context) (*StudentProfile, error) {
+	resp := struct {
+		*StudentProfile
+		Photo string
+	}{StudentProfile: new(StudentProfile)}
+
+	err := c.invoke(ctx, svueRequest{
+		methodName:   "StudentInfo",
+		params:       c.childParams(),
+		skipLoginLog: true,
+	}, "StudentInfo", &resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sp := resp.StudentProfile
+	sp.Address = strings.TrimSpace(strings.Replace(sp.Address, "<br>", "\n", -1))
+
+	if photo := strings.TrimSpace(resp.Photo); photo != "" {
+		b, err := base64.StdEncoding.DecodeString(photo)
+
+		if err != nil {
+			return nil, SVUEError{
+				OrigError: err,
+				Code:      DecodingError,
+			}
+		}
+
+		sp.Photo = b
+	}
+
+	return sp, nil
 }
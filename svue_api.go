@@ -2,16 +2,30 @@ package govue
 
 import (
 	"bytes"
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
 )
 
 type SVUEResponse struct {
-	XMLName xml.Name `xml:"Envelope"`
-	Result  string   `xml:"Body>ProcessWebServiceRequestResponse>ProcessWebServiceRequestResult"`
+	XMLName xml.Name   `xml:"Envelope"`
+	Result  string     `xml:"Body>ProcessWebServiceRequestResponse>ProcessWebServiceRequestResult"`
+	Fault   *SOAPFault `xml:"Body>Fault"`
+}
+
+// A SOAPFault is the fault element of a SOAP envelope, which the server returns
+// instead of a result when it fails to process a request.
+type SOAPFault struct {
+	Code   string `xml:"faultcode"`
+	String string `xml:"faultstring"`
+	Actor  string `xml:"faultactor"`
+
+	// Detail holds the raw content of the fault's detail element.
+	Detail struct {
+		InnerXML string `xml:",innerxml"`
+	} `xml:"detail"`
 }
 
 type SVUESignInResponse struct {
@@ -20,116 +34,205 @@ type SVUESignInResponse struct {
 }
 
 const (
-	soapAction   = "http://edupoint.com/webservices/ProcessWebServiceRequest"
-	signInRequestBody = `<?xml version="1.0" encoding="utf-8"?>
-		<soap:Envelope xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
-			<soap:Body>
-				<ProcessWebServiceRequest xmlns="http://edupoint.com/webservices/">
-					<userID>%s</userID>
-					<password>%s</password>
-					<skipLoginLog>0</skipLoginLog>
-					<parent>0</parent>
-					<webServiceHandleName>PXPWebServices</webServiceHandleName>
-					<methodName>ChildList</methodName>
-					<paramStr/>
-				</ProcessWebServiceRequest>
-			</soap:Body>
-		</soap:Envelope>`
-	getGradesRequestBody = `<?xml version="1.0" encoding="utf-8"?>
+	soapAction  = "http://edupoint.com/webservices/ProcessWebServiceRequest"
+	requestBody = `<?xml version="1.0" encoding="utf-8"?>
 		<soap:Envelope xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
 			<soap:Body>
 				<ProcessWebServiceRequest xmlns="http://edupoint.com/webservices/">
 					<userID>%s</userID>
 					<password>%s</password>
-					<skipLoginLog>1</skipLoginLog>
-					<parent>0</parent>
-					<webServiceHandleName>PXPWebServices</webServiceHandleName>
-					<methodName>Gradebook</methodName>
+					<skipLoginLog>%d</skipLoginLog>
+					<parent>%d</parent>
+					<webServiceHandleName>%s</webServiceHandleName>
+					<methodName>%s</methodName>
 					%s
 				</ProcessWebServiceRequest>
 			</soap:Body>
 		</soap:Envelope>`
-	getGradesParamStr            = `<paramStr>&lt;Parms&gt;&lt;ChildIntID&gt;0&lt;/ChildIntID&gt;&lt;/Parms&gt;</paramStr>`
-	getGradesParamStrGradePeriod = `<paramStr>&lt;Parms&gt;&lt;ChildIntID&gt;0&lt;/ChildIntID&gt;&lt;ReportPeriod&gt;%d&lt;/ReportPeriod&gt;&lt;/Parms&gt;</paramStr>`
+	emptyParamStr = `<paramStr/>`
+
+	pxpWebServicesHandle = "PXPWebServices"
 )
 
+// An svueRequest describes a single call to a ProcessWebServiceRequest method.
+type svueRequest struct {
+	methodName   string
+	params       interface{}
+	skipLoginLog bool
+
+	// handleName is the web service to which the method belongs, which is
+	// PXPWebServices unless set.
+	handleName string
+}
 
+// SignInStudent validates a student's credentials against the given endpoint and
+// returns the student's basic information. It is shorthand for creating a Client
+// with NewClient and calling its SignIn method.
 func SignInStudent(username, password, endpoint string) (*Student, error) {
-	escapedAuth, err := escapeStringsForXml(username, password)
+	return SignInStudentContext(context.Background(), username, password, endpoint)
+}
+
+// SignInStudentContext is like SignInStudent, but aborts the request when ctx
+// is cancelled or its deadline passes.
+func SignInStudentContext(ctx context.Context, username, password, endpoint string) (*Student, error) {
+	return NewClient(username, password, WithEndpoint(endpoint)).SignInContext(ctx)
+}
+
+func GetStudentGrades(username, password, endpoint string) (*Gradebook, error) {
+	return GetStudentGradesForGradingPeriod(username, password, endpoint, -1)
+}
+
+// GetStudentGradesContext is like GetStudentGrades, but aborts the request when
+// ctx is cancelled or its deadline passes.
+func GetStudentGradesContext(ctx context.Context, username, password, endpoint string) (*Gradebook, error) {
+	return GetStudentGradesForGradingPeriodContext(ctx, username, password, endpoint, -1)
+}
+
+func GetStudentGradesForGradingPeriod(username, password, endpoint string, gradingPeriodIndex int) (*Gradebook, error) {
+	return GetStudentGradesForGradingPeriodContext(context.Background(), username, password, endpoint, gradingPeriodIndex)
+}
+
+// GetStudentGradesForGradingPeriodContext is like GetStudentGradesForGradingPeriod,
+// but aborts the request when ctx is cancelled or its deadline passes.
+func GetStudentGradesForGradingPeriodContext(ctx context.Context, username, password, endpoint string, gradingPeriodIndex int) (*Gradebook, error) {
+	return NewClient(username, password, WithEndpoint(endpoint)).GradebookForPeriodContext(ctx, gradingPeriodIndex)
+}
+
+// encodeRequest builds the SOAP envelope of the request described by sr.
+func (c *Client) encodeRequest(sr svueRequest) (string, error) {
+	escapedAuth, err := escapeStringsForXml(c.username, c.password)
 
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	username = escapedAuth[0]
-	password = escapedAuth[1]
-
-	signInBody := fmt.Sprintf(signInRequestBody, username, password)
-	sResp, err := callApi(strings.NewReader(signInBody), endpoint)
+	paramStr, err := encodeParamStr(sr.params)
 
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	return decodeStudentSignIn(sResp)
-}
+	skipLoginLog, parent := 0, 0
 
-func GetStudentGrades(username, password, endpoint string) (*Gradebook, error) {
-	return GetStudentGradesForGradingPeriod(username, password, endpoint, -1)
-}
+	if sr.skipLoginLog {
+		skipLoginLog = 1
+	}
 
-func GetStudentGradesForGradingPeriod(username,  password, endpoint string, gradingPeriodIndex int) (*Gradebook, error) {
-	var paramStr string
+	if c.parent {
+		parent = 1
+	}
 
-	if gradingPeriodIndex < 0 {
-		paramStr = getGradesParamStr
-	} else {
-		paramStr = fmt.Sprintf(getGradesParamStrGradePeriod, gradingPeriodIndex)
+	handleName := sr.handleName
+
+	if handleName == "" {
+		handleName = pxpWebServicesHandle
 	}
 
-	escapedAuth, err := escapeStringsForXml(username, password)
+	return fmt.Sprintf(requestBody, escapedAuth[0], escapedAuth[1], skipLoginLog, parent, handleName, sr.methodName, paramStr), nil
+}
 
-	if err != nil {
-		return nil, err
+// encodeParamStr encodes params as the `<Parms>` document expected by
+// ProcessWebServiceRequest and escapes it into a `<paramStr>` element.
+func encodeParamStr(params interface{}) (string, error) {
+	if params == nil {
+		return emptyParamStr, nil
+	}
+
+	buf := new(bytes.Buffer)
+	e := xml.NewEncoder(buf)
+
+	if err := e.EncodeElement(params, xml.StartElement{Name: xml.Name{Local: "Parms"}}); err != nil {
+		return "", err
 	}
 
-	username = escapedAuth[0]
-	password = escapedAuth[1]
+	if err := e.Flush(); err != nil {
+		return "", err
+	}
 
-	gradesBody := fmt.Sprintf(getGradesRequestBody, username, password, paramStr)
-	sResp, err := callApi(strings.NewReader(gradesBody), endpoint)
+	escaped, err := escapeXmlText(buf.String())
 
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	return decodeStudentGrades(sResp)
+	return "<paramStr>" + escaped + "</paramStr>", nil
 }
 
-func callApi(body io.Reader, endpoint string) (*SVUEResponse, error) {
-	req, err := newSVueRequest(body, endpoint)
+// callApi sends the request body to the Client's endpoint and decodes the
+// result document of the response, which must be named expectedElement, into v
+// as the response is read. If ctx is done before the response has been read,
+// ctx.Err() is returned as is, so callers can compare it against
+// context.Canceled and context.DeadlineExceeded.
+func (c *Client) callApi(ctx context.Context, body io.Reader, expectedElement string, v interface{}) error {
+	req, err := newSVueRequest(ctx, body, c.endpoint)
 
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	if c.rateLimiter != nil {
+		release, err := c.rateLimiter.wait(ctx, c.endpoint)
+
+		if err != nil {
+			return err
+		}
+
+		defer release()
 	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.httpClient.Do(req)
 
 	if err != nil {
-		return nil, err
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		return err
 	}
 
 	defer resp.Body.Close()
 
-	buf := new(bytes.Buffer)
-	buf.ReadFrom(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// The fault is decoded from as much of the body as maxFaultBodyLen
+		// allows; only the start of the body is kept in the error.
+		errBody, err := io.ReadAll(io.LimitReader(resp.Body, maxFaultBodyLen))
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		if err != nil {
+			return err
+		}
+
+		// Drain the rest of the body so that the connection may be reused.
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBodyLen))
+
+		return decodeErrorResponse(resp.StatusCode, errBody)
+	}
+
+	err = decodeSVUEResponse(resp.StatusCode, resp.Body, expectedElement, v)
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// Drain the rest of the envelope so that the connection may be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBodyLen))
 
-	return decodeSVUEResponse(buf)
+	return nil
 }
 
-func newSVueRequest(body io.Reader, endpoint string) (*http.Request, error) {
-	req, err := http.NewRequest("POST", endpoint, body)
+func newSVueRequest(ctx context.Context, body io.Reader, endpoint string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, body)
 
 	if err != nil {
 		return nil, err
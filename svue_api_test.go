@@ -0,0 +1,63 @@
+package govue
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCallApiLimitsErrorBody(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(strings.Repeat("x", 1<<20)))
+	}))
+	defer s.Close()
+
+	_, err := NewClient("student", "secret", WithEndpoint(s.URL)).SignIn()
+
+	var he HTTPStatusError
+
+	if !errors.As(err, &he) {
+		t.Fatalf("SignIn() error = %v, want an HTTPStatusError", err)
+	}
+
+	if he.StatusCode != http.StatusBadGateway {
+		t.Errorf("StatusCode = %d, want %d", he.StatusCode, http.StatusBadGateway)
+	}
+
+	if want := strings.Repeat("x", maxErrorBodyLen) + "..."; he.Body != want {
+		t.Errorf("Body has %d bytes, want the first %d followed by `...`", len(he.Body), maxErrorBodyLen)
+	}
+}
+
+func TestCallApiDecodesLongFault(t *testing.T) {
+	trace := "System.Exception: Boom\n" + strings.Repeat("   at Edupoint.PXP.ProcessWebServiceRequest()\n", 500)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?>` +
+			`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><soap:Fault>` +
+			`<faultcode>soap:Server</faultcode><faultstring>` + trace + `</faultstring>` +
+			`</soap:Fault></soap:Body></soap:Envelope>`))
+	}))
+	defer s.Close()
+
+	_, err := NewClient("student", "secret", WithEndpoint(s.URL)).SignIn()
+
+	var fe SOAPFaultError
+
+	if !errors.As(err, &fe) {
+		t.Fatalf("SignIn() error = %v, want a SOAPFaultError", err)
+	}
+
+	if fe.Fault.Code != "soap:Server" || fe.Fault.String != trace {
+		t.Errorf("Fault = %q with a faultstring of %d bytes, want soap:Server with %d bytes", fe.Fault.Code, len(fe.Fault.String), len(trace))
+	}
+
+	if len(fe.Body) != maxErrorBodyLen+len("...") {
+		t.Errorf("Body has %d bytes, want the first %d followed by `...`", len(fe.Body), maxErrorBodyLen)
+	}
+}
@@ -0,0 +1,151 @@
+package govue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// defaultYearGradebookConcurrency is the number of gradebooks fetched at once
+// by YearGradebook unless YearGradebookOptions.MaxConcurrency is set.
+const defaultYearGradebookConcurrency = 4
+
+// A YearGradebook holds a student's gradebooks for many grading periods of the
+// school year, such as every period of the year.
+type YearGradebook struct {
+	// GradingPeriods holds all the grading periods of the student's school, as
+	// listed by the gradebook for the current grading period.
+	GradingPeriods []*GradingPeriod
+
+	// CurrentGradingPeriod is the school's current grading period.
+	CurrentGradingPeriod *GradingPeriod
+
+	// Gradebooks holds the gradebook of each grading period which was fetched,
+	// keyed by GradingPeriod.Index.
+	Gradebooks map[int]*Gradebook
+
+	// Errors holds the error with which fetching each remaining grading period's
+	// gradebook failed, keyed by GradingPeriod.Index.
+	Errors map[int]error
+}
+
+// YearGradebookOptions selects the grading periods fetched by YearGradebook.
+type YearGradebookOptions struct {
+	// GradingPeriods holds the indexes of the grading periods to fetch. If it
+	// is empty, every grading period is fetched.
+	GradingPeriods []int
+
+	// MaxConcurrency is the maximum number of gradebooks fetched at once. Zero
+	// means 4.
+	MaxConcurrency int
+}
+
+// YearGradebook returns the student's gradebooks for the grading periods
+// selected by opts, or for every grading period if opts is nil. The gradebook
+// for the current grading period is fetched first, to list the school's
+// grading periods; if that fails, its error is returned. A failure to fetch
+// any other grading period's gradebook is reported in YearGradebook.Errors
+// rather than failing the whole year.
+func (c *Client) YearGradebook(opts *YearGradebookOptions) (*YearGradebook, error) {
+	return c.YearGradebookContext(context.Background(), opts)
+}
+
+// YearGradebookContext is like YearGradebook, but aborts the requests when ctx
+// is cancelled or its deadline passes. Grading periods whose gradebooks had
+// not been fetched by then are reported in YearGradebook.Errors.
+func (c *Client) YearGradebookContext(ctx context.Context, opts *YearGradebookOptions) (*YearGradebook, error) {
+	if opts == nil {
+		opts = new(YearGradebookOptions)
+	}
+
+	current, err := c.GradebookContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	yg := &YearGradebook{
+		GradingPeriods:       current.GradingPeriods,
+		CurrentGradingPeriod: current.CurrentGradingPeriod,
+		Gradebooks:           make(map[int]*Gradebook),
+		Errors:               make(map[int]error),
+	}
+
+	periods := make(map[int]*GradingPeriod, len(current.GradingPeriods))
+
+	for _, gp := range current.GradingPeriods {
+		periods[gp.Index] = gp
+	}
+
+	indexes := opts.GradingPeriods
+
+	if len(indexes) == 0 {
+		for _, gp := range current.GradingPeriods {
+			indexes = append(indexes, gp.Index)
+		}
+	}
+
+	concurrency := opts.MaxConcurrency
+
+	if concurrency < 1 {
+		concurrency = defaultYearGradebookConcurrency
+	}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+	)
+
+	done := func(index int, gb *Gradebook, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err != nil {
+			yg.Errors[index] = err
+		} else {
+			yg.Gradebooks[index] = gb
+		}
+	}
+
+	for _, index := range indexes {
+		gp, ok := periods[index]
+
+		switch {
+		case !ok:
+			done(index, nil, SVUEError{
+				OrigError: fmt.Errorf("No grading period with index %d", index),
+				Code:      UnexpectedError,
+			})
+
+			continue
+		case current.CurrentGradingPeriod != nil && gp.Name == current.CurrentGradingPeriod.Name:
+			// The current grading period's gradebook has already been fetched.
+			done(index, current, nil)
+
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			done(index, nil, ctx.Err())
+
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(index int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			gb, err := c.GradebookForPeriodContext(ctx, index)
+			done(index, gb, err)
+		}(index)
+	}
+
+	wg.Wait()
+
+	return yg, nil
+}